@@ -0,0 +1,102 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package apigatewayauthorizerevt
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestResponseContextValidate(t *testing.T) {
+	s := "pointer"
+	tests := []struct {
+		name string
+		ctx  ResponseContext
+		want string
+	}{
+		{"valid", ResponseContext{"s": "a", "b": true, "i": 42, "u": uint8(1), "f": 3.14}, ""},
+		{"empty", ResponseContext{}, ""},
+		{"nested object", ResponseContext{"user": map[string]string{"name": "jdoe"}},
+			`apigatewayauthorizerevt: context value of key "user" must be a string, a number or a boolean, not map[string]string`},
+		{"array", ResponseContext{"roles": []string{"admin"}},
+			`apigatewayauthorizerevt: context value of key "roles" must be a string, a number or a boolean, not []string`},
+		{"nil", ResponseContext{"nothing": nil},
+			`apigatewayauthorizerevt: context value of key "nothing" must be a string, a number or a boolean, not <nil>`},
+		{"pointer", ResponseContext{"ptr": &s},
+			`apigatewayauthorizerevt: context value of key "ptr" must be a string, a number or a boolean, not *string`},
+		{"struct", ResponseContext{"obj": struct{ A int }{1}},
+			`apigatewayauthorizerevt: context value of key "obj" must be a string, a number or a boolean, not struct { A int }`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ctx.Validate()
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("got %v", err)
+			case tt.want != "" && (err == nil || err.Error() != tt.want):
+				t.Errorf("got %v, want %s", err, tt.want)
+			}
+
+			_, err = json.Marshal(&Response{Context: tt.ctx})
+			switch {
+			case tt.want == "" && err != nil:
+				t.Errorf("got %v when marshalling", err)
+			case tt.want != "" && (err == nil || !strings.HasSuffix(err.Error(), tt.want)):
+				t.Errorf("got %v when marshalling, want %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponseMarshal(t *testing.T) {
+	resp := &Response{
+		PrincipalID: "user|a1b2c3d4",
+		PolicyDocument: PolicyDocument{
+			Version: PolicyVersion,
+			Statement: []Statement{{
+				Action:   StringList{"execute-api:Invoke"},
+				Effect:   EffectAllow,
+				Resource: StringList{"arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/prod/GET/pets"},
+			}},
+		},
+		Context:            ResponseContext{"booleanKey": true, "numberKey": 123, "stringKey": "value"},
+		UsageIdentifierKey: "a1b2c3d4e5f6g7h8i9j0",
+	}
+
+	got, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"principalId":"user|a1b2c3d4",` +
+		`"policyDocument":{"Version":"2012-10-17","Statement":[{"Action":"execute-api:Invoke","Effect":"Allow","Resource":"arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/prod/GET/pets"}]},` +
+		`"context":{"booleanKey":true,"numberKey":123,"stringKey":"value"},` +
+		`"usageIdentifierKey":"a1b2c3d4e5f6g7h8i9j0"}`
+	if string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+
+	resp.Context, resp.UsageIdentifierKey = nil, ""
+	got, err = json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := string(got); strings.Contains(s, "context") || strings.Contains(s, "usageIdentifierKey") {
+		t.Errorf("got %s, want no context nor usage identifier key", s)
+	}
+}
@@ -21,94 +21,105 @@ import "encoding/json"
 // Identity provides identity information about the API caller.
 type Identity struct {
 	// The API owner key associated with the API.
-	APIKey string
+	APIKey string `json:"apiKey"`
 
 	// The source IP address of the TCP connection making the request to
 	// Amazon API Gateway.
-	SourceIP string
+	SourceIP string `json:"sourceIp"`
 }
 
 // RequestContext provides contextual information about an Amazon API Gateway
 // Proxy Custom Authorizer event.
 type RequestContext struct {
 	// The path as defined in Amazon API Gateway.
-	Path string
+	Path string `json:"path"`
 
 	// The AWS account ID associated with the API.
-	AccountID string
+	AccountID string `json:"accountId"`
 
 	// The deployment stage of the API call (for example, Beta or Prod).
-	Stage string
+	Stage string `json:"stage"`
 
 	// An automatically generated ID for the API call.
-	RequestID string
+	RequestID string `json:"requestId"`
 
 	// The API caller identification information.
-	Identity *Identity
+	Identity *Identity `json:"identity"`
 
 	// The resource path as defined in Amazon API Gateway.
-	ResourcePath string
+	ResourcePath string `json:"resourcePath"`
 
 	// The incoming request HTTP method name.
 	// Valid values include: DELETE, GET, HEAD, OPTIONS, PATCH, POST, and
 	// PUT.
-	HTTPMethod string
+	HTTPMethod string `json:"httpMethod"`
 
 	// The identifier Amazon API Gateway assigns to the API.
-	APIID string
+	APIID string `json:"apiId"`
 
 	// The identifier Amazon API Gateway assigns to the resource.
-	ResourceID string
+	ResourceID string `json:"resourceId"`
 }
 
 // Event represents an input to a REQUEST authorizer for an API method with a
 // proxy integration.
 type Event struct {
 	// Type property specifies the authorizer type ("TOKEN" or "REQUEST").
-	Type string
+	Type string `json:"type"`
 
 	// MethodARN is the ARN of the incoming method request and is populated by
 	// API Gateway in accordance with the custom authorizer configuration.
-	MethodARN string
+	MethodARN string `json:"methodArn"`
 
 	// AuthorizationToken has called supplied token which is allow, deny,
 	// unauthorized, or any other string value. An empty string value is the
 	// same as unauthorized (only for TOKEN type).
-	AuthorizationToken string
+	AuthorizationToken string `json:"authorizationToken,omitempty"`
 
 	// The resource path with raw placeholders as defined in
 	// Amazon API Gateway.
-	Resource string
+	Resource string `json:"resource,omitempty"`
 
 	// The real path corresponding to the path parameters injected into the
 	// Resource placeholders.
-	Path string
+	Path string `json:"path,omitempty"`
 
 	// The incoming request HTTP method name.
 	// Valid values include: DELETE, GET, HEAD, OPTIONS, PATCH, POST, and
 	// PUT.
-	HTTPMethod string
+	HTTPMethod string `json:"httpMethod,omitempty"`
 
 	// The incoming request HTTP headers.
 	// Duplicate entries are not supported.
-	Headers map[string]string
+	Headers map[string]string `json:"headers,omitempty"`
 
 	// The incoming request query string parameters.
 	// Duplicate entries are not supported.
-	QueryStringParameters map[string]string
+	QueryStringParameters map[string]string `json:"queryStringParameters,omitempty"`
 
 	// The incoming request path parameters corresponding to the resource
 	// path placeholders values as defined in Resource.
-	PathParameters map[string]string
+	PathParameters map[string]string `json:"pathParameters,omitempty"`
 
 	// The name-value pairs defined as configuration attributes associated
 	// with the deployment stage of the API.
-	StageVariables map[string]string
+	StageVariables map[string]string `json:"stageVariables,omitempty"`
 
 	// The contextual information associated with the API call.
-	RequestContext *RequestContext
+	RequestContext *RequestContext `json:"requestContext,omitempty"`
 }
 
+// StringList represents an IAM policy element which accepts either a single
+// string or an array of strings.
+type StringList []string
+
+// Condition represents the conditions of an IAM policy statement, keyed by
+// condition operator then by condition key. Values are either a single value
+// or an array of values.
+//
+// For instance: Condition{"IpAddress": {"aws:SourceIp": "203.0.113.0/24"}}
+type Condition map[string]map[string]interface{}
+
 // Statement represents AWS IAM policy statement.
 //
 // More detail about IAM policy statement
@@ -116,26 +127,35 @@ type Event struct {
 type Statement struct {
 	// Action describes the specific action or actions that will be
 	// allowed or denied. Statements must include either an Action or NotAction element.
-	Action string
+	Action StringList `json:"Action"`
 
 	// Effect is required and specifies whether the statement results
 	// in an allow or an explicit deny. Valid values for Effect are Allow and Deny.
-	Effect string
+	Effect string `json:"Effect"`
 
 	// Resource specifies the object or objects that the statement covers.
-	Resource string
+	Resource StringList `json:"Resource"`
+
+	// Condition specifies the circumstances under which the statement
+	// applies.
+	Condition Condition `json:"Condition,omitempty"`
 }
 
 // PolicyDocument represents an AWS IAM policy document.
 type PolicyDocument struct {
 	// Version specifies the language syntax rules that are to be used
 	// to process this policy. The Version must appear before the Statement element.
-	Version string
+	Version string `json:"Version"`
 
 	// Statement represents an AWS IAM policy statement.
-	Statement []Statement
+	Statement []Statement `json:"Statement"`
 }
 
+// ResponseContext represents the context of an Amazon API Gateway Custom
+// Authorizer response. Values must be strings, numbers or booleans; objects
+// and arrays are rejected by Amazon API Gateway and fail the marshalling.
+type ResponseContext map[string]interface{}
+
 // Response represents an output from an Amazon API Gateway Custom Authorizer.
 type Response struct {
 	// The principal user identification associated with the token sent by the client.
@@ -146,7 +166,11 @@ type Response struct {
 
 	// API Gateway passes the context object from a custom authorizer directly to
 	// the backend Lambda function as part of the input event.
-	Context map[string]string `json:"context"`
+	Context ResponseContext `json:"context,omitempty"`
+
+	// The API key used by Amazon API Gateway to identify the usage plan of
+	// the caller when the API key source is set to AUTHORIZER.
+	UsageIdentifierKey string `json:"usageIdentifierKey,omitempty"`
 }
 
 // String returns the string representation.
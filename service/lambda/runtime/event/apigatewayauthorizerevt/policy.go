@@ -0,0 +1,224 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package apigatewayauthorizerevt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PolicyVersion is the IAM policy language version of the documents built by
+// PolicyBuilder.
+const PolicyVersion = "2012-10-17"
+
+// The effects of an IAM policy statement.
+const (
+	EffectAllow = "Allow"
+	EffectDeny  = "Deny"
+)
+
+// The HTTP verbs accepted in a method ARN. VerbAll matches every verb.
+const (
+	VerbGet     = "GET"
+	VerbPost    = "POST"
+	VerbPut     = "PUT"
+	VerbPatch   = "PATCH"
+	VerbHead    = "HEAD"
+	VerbDelete  = "DELETE"
+	VerbOptions = "OPTIONS"
+	VerbAll     = "*"
+)
+
+// ErrInvalidMethodARN is returned when a method ARN cannot be parsed.
+var ErrInvalidMethodARN = errors.New("apigatewayauthorizerevt: invalid method ARN")
+
+// MethodARN represents the ARN of an Amazon API Gateway method, in the form
+// arn:aws:execute-api:region:account-id:api-id/stage/verb/resource.
+type MethodARN struct {
+	// The AWS partition, usually "aws".
+	Partition string
+
+	// The AWS region of the API.
+	Region string
+
+	// The AWS account ID owning the API.
+	AccountID string
+
+	// The identifier Amazon API Gateway assigns to the API.
+	APIID string
+
+	// The deployment stage of the API.
+	Stage string
+
+	// The HTTP verb of the method.
+	Verb string
+
+	// The resource path of the method, without leading slash.
+	Resource string
+}
+
+// ParseMethodARN parses a method ARN, such as Event.MethodARN.
+func ParseMethodARN(s string) (*MethodARN, error) {
+	parts := strings.SplitN(s, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "execute-api" {
+		return nil, ErrInvalidMethodARN
+	}
+
+	path := strings.SplitN(parts[5], "/", 4)
+	if len(path) < 3 {
+		return nil, ErrInvalidMethodARN
+	}
+
+	arn := &MethodARN{
+		Partition: parts[1],
+		Region:    parts[3],
+		AccountID: parts[4],
+		APIID:     path[0],
+		Stage:     path[1],
+		Verb:      path[2],
+	}
+	if len(path) == 4 {
+		arn.Resource = path[3]
+	}
+	return arn, nil
+}
+
+// String returns the string representation.
+func (a *MethodARN) String() string {
+	return fmt.Sprintf("arn:%s:execute-api:%s:%s:%s/%s/%s/%s",
+		a.Partition, a.Region, a.AccountID, a.APIID, a.Stage, a.Verb, a.Resource)
+}
+
+// PolicyBuilder builds the IAM policy document of an Amazon API Gateway Custom
+// Authorizer response.
+//
+// Methods allowed or denied without condition are grouped into a single
+// statement per effect, while each method with conditions gets its own
+// statement.
+type PolicyBuilder struct {
+	base  MethodARN
+	allow []string
+	deny  []string
+	extra []Statement
+	err   error
+}
+
+// NewPolicyBuilder returns a PolicyBuilder for the API, stage and account the
+// given method ARN, usually Event.MethodARN, belongs to.
+func NewPolicyBuilder(methodARN string) (*PolicyBuilder, error) {
+	arn, err := ParseMethodARN(methodARN)
+	if err != nil {
+		return nil, err
+	}
+	return &PolicyBuilder{base: *arn}, nil
+}
+
+// AllowAll allows every method of the API stage.
+func (b *PolicyBuilder) AllowAll() *PolicyBuilder {
+	return b.AllowMethod(VerbAll, "*")
+}
+
+// DenyAll denies every method of the API stage.
+func (b *PolicyBuilder) DenyAll() *PolicyBuilder {
+	return b.DenyMethod(VerbAll, "*")
+}
+
+// AllowMethod allows the method identified by the given HTTP verb and resource
+// path. Both accept the "*" wildcard.
+func (b *PolicyBuilder) AllowMethod(verb, resource string) *PolicyBuilder {
+	return b.AllowMethodWithConditions(verb, resource, nil)
+}
+
+// DenyMethod denies the method identified by the given HTTP verb and resource
+// path. Both accept the "*" wildcard.
+func (b *PolicyBuilder) DenyMethod(verb, resource string) *PolicyBuilder {
+	return b.DenyMethodWithConditions(verb, resource, nil)
+}
+
+// AllowMethodWithConditions allows the method identified by the given HTTP verb
+// and resource path under the given conditions.
+func (b *PolicyBuilder) AllowMethodWithConditions(verb, resource string, cond Condition) *PolicyBuilder {
+	return b.add(EffectAllow, verb, resource, cond)
+}
+
+// DenyMethodWithConditions denies the method identified by the given HTTP verb
+// and resource path under the given conditions.
+func (b *PolicyBuilder) DenyMethodWithConditions(verb, resource string, cond Condition) *PolicyBuilder {
+	return b.add(EffectDeny, verb, resource, cond)
+}
+
+func (b *PolicyBuilder) add(effect, verb, resource string, cond Condition) *PolicyBuilder {
+	switch verb = strings.ToUpper(verb); verb {
+	case VerbGet, VerbPost, VerbPut, VerbPatch, VerbHead, VerbDelete, VerbOptions, VerbAll:
+	default:
+		if b.err == nil {
+			b.err = fmt.Errorf("apigatewayauthorizerevt: invalid HTTP verb: %s", verb)
+		}
+		return b
+	}
+
+	arn := b.base
+	arn.Verb = verb
+	arn.Resource = strings.TrimPrefix(resource, "/")
+	res := arn.String()
+
+	switch {
+	case len(cond) > 0:
+		b.extra = append(b.extra, Statement{
+			Action:    StringList{"execute-api:Invoke"},
+			Effect:    effect,
+			Resource:  StringList{res},
+			Condition: cond,
+		})
+	case effect == EffectAllow:
+		b.allow = append(b.allow, res)
+	default:
+		b.deny = append(b.deny, res)
+	}
+	return b
+}
+
+// Build returns the policy document. It fails if an invalid HTTP verb has been
+// given or if no method has been allowed nor denied.
+func (b *PolicyBuilder) Build() (PolicyDocument, error) {
+	if b.err != nil {
+		return PolicyDocument{}, b.err
+	}
+	if len(b.allow) == 0 && len(b.deny) == 0 && len(b.extra) == 0 {
+		return PolicyDocument{}, errors.New("apigatewayauthorizerevt: no statement in policy")
+	}
+
+	doc := PolicyDocument{Version: PolicyVersion}
+	if len(b.allow) > 0 {
+		doc.Statement = append(doc.Statement, Statement{
+			Action:   StringList{"execute-api:Invoke"},
+			Effect:   EffectAllow,
+			Resource: StringList(b.allow),
+		})
+	}
+	if len(b.deny) > 0 {
+		doc.Statement = append(doc.Statement, Statement{
+			Action:   StringList{"execute-api:Invoke"},
+			Effect:   EffectDeny,
+			Resource: StringList(b.deny),
+		})
+	}
+	doc.Statement = append(doc.Statement, b.extra...)
+
+	return doc, nil
+}
@@ -0,0 +1,202 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package apigatewayauthorizerevt
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseMethodARN(t *testing.T) {
+	tests := []struct {
+		arn  string
+		want *MethodARN
+	}{
+		{
+			"arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/prod/GET/pets",
+			&MethodARN{"aws", "us-east-1", "123456789012", "ymy8tbxw7b", "prod", "GET", "pets"},
+		},
+		{
+			"arn:aws:execute-api:us-west-2:123456789012:ymy8tbxw7b/prod/POST/pets/42/owner",
+			&MethodARN{"aws", "us-west-2", "123456789012", "ymy8tbxw7b", "prod", "POST", "pets/42/owner"},
+		},
+		{
+			"arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/*/*/*",
+			&MethodARN{"aws", "us-east-1", "123456789012", "ymy8tbxw7b", "*", "*", "*"},
+		},
+		{
+			"arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/test/GET/pets/*",
+			&MethodARN{"aws", "us-east-1", "123456789012", "ymy8tbxw7b", "test", "GET", "pets/*"},
+		},
+		{
+			"arn:aws-cn:execute-api:cn-north-1:123456789012:ymy8tbxw7b/prod/DELETE/",
+			&MethodARN{"aws-cn", "cn-north-1", "123456789012", "ymy8tbxw7b", "prod", "DELETE", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMethodARN(tt.arn)
+		if err != nil {
+			t.Errorf("%s: %v", tt.arn, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.arn, got, tt.want)
+		}
+		if s := got.String(); s != tt.arn {
+			t.Errorf("%s: got string %s", tt.arn, s)
+		}
+	}
+}
+
+func TestParseMethodARNInvalid(t *testing.T) {
+	for _, arn := range []string{
+		"",
+		"ymy8tbxw7b/prod/GET/pets",
+		"arn:aws:lambda:us-east-1:123456789012:function:authorizer",
+		"urn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/prod/GET/pets",
+		"arn:aws:execute-api:us-east-1:ymy8tbxw7b/prod/GET/pets",
+		"arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/prod",
+		"arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b",
+	} {
+		if _, err := ParseMethodARN(arn); err != ErrInvalidMethodARN {
+			t.Errorf("%q: got %v, want %v", arn, err, ErrInvalidMethodARN)
+		}
+	}
+}
+
+const testMethodARN = "arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/prod/GET/pets"
+
+func TestPolicyBuilder(t *testing.T) {
+	b, err := NewPolicyBuilder(testMethodARN)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := b.
+		AllowMethod("get", "/pets").
+		DenyMethod(VerbDelete, "pets/*").
+		AllowMethod(VerbPost, "pets/{petId}/owner").
+		AllowMethodWithConditions(VerbPut, "pets/*", Condition{
+			"IpAddress": {"aws:SourceIp": []string{"203.0.113.0/24", "198.51.100.0/24"}},
+		}).
+		DenyMethodWithConditions(VerbAll, "admin", Condition{
+			"DateGreaterThan": {"aws:CurrentTime": "2017-12-31T23:59:59Z"},
+		}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Version":"2012-10-17","Statement":[` +
+		`{"Action":"execute-api:Invoke","Effect":"Allow","Resource":["arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/prod/GET/pets","arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/prod/POST/pets/{petId}/owner"]},` +
+		`{"Action":"execute-api:Invoke","Effect":"Deny","Resource":"arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/prod/DELETE/pets/*"},` +
+		`{"Action":"execute-api:Invoke","Effect":"Allow","Resource":"arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/prod/PUT/pets/*","Condition":{"IpAddress":{"aws:SourceIp":["203.0.113.0/24","198.51.100.0/24"]}}},` +
+		`{"Action":"execute-api:Invoke","Effect":"Deny","Resource":"arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/prod/*/admin","Condition":{"DateGreaterThan":{"aws:CurrentTime":"2017-12-31T23:59:59Z"}}}]}`
+	if string(got) != want {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+func TestPolicyBuilderAll(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func(*PolicyBuilder) *PolicyBuilder
+		want  Statement
+	}{
+		{"allow", (*PolicyBuilder).AllowAll, Statement{
+			Action:   StringList{"execute-api:Invoke"},
+			Effect:   EffectAllow,
+			Resource: StringList{"arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/prod/*/*"},
+		}},
+		{"deny", (*PolicyBuilder).DenyAll, Statement{
+			Action:   StringList{"execute-api:Invoke"},
+			Effect:   EffectDeny,
+			Resource: StringList{"arn:aws:execute-api:us-east-1:123456789012:ymy8tbxw7b/prod/*/*"},
+		}},
+	}
+
+	for _, tt := range tests {
+		b, _ := NewPolicyBuilder(testMethodARN)
+		doc, err := tt.build(b).Build()
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		want := PolicyDocument{Version: PolicyVersion, Statement: []Statement{tt.want}}
+		if !reflect.DeepEqual(doc, want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, doc, want)
+		}
+	}
+}
+
+func TestPolicyBuilderErrors(t *testing.T) {
+	if _, err := NewPolicyBuilder("arn:aws:lambda:us-east-1:123456789012:function:f"); err != ErrInvalidMethodARN {
+		t.Errorf("got %v, want %v", err, ErrInvalidMethodARN)
+	}
+
+	b, _ := NewPolicyBuilder(testMethodARN)
+	if _, err := b.Build(); err == nil || err.Error() != "apigatewayauthorizerevt: no statement in policy" {
+		t.Errorf("got %v for an empty policy", err)
+	}
+
+	b, _ = NewPolicyBuilder(testMethodARN)
+	_, err := b.AllowMethod("FETCH", "pets").AllowMethod("TRACE", "pets").AllowAll().Build()
+	if err == nil || err.Error() != "apigatewayauthorizerevt: invalid HTTP verb: FETCH" {
+		t.Errorf("got %v for an invalid verb", err)
+	}
+}
+
+func TestStringList(t *testing.T) {
+	tests := []struct {
+		data string
+		want StringList
+	}{
+		{`"execute-api:Invoke"`, StringList{"execute-api:Invoke"}},
+		{`["a","b"]`, StringList{"a", "b"}},
+		{`[]`, StringList{}},
+	}
+
+	for _, tt := range tests {
+		var l StringList
+		if err := json.Unmarshal([]byte(tt.data), &l); err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if !reflect.DeepEqual(l, tt.want) {
+			t.Errorf("%s: got %#v, want %#v", tt.data, l, tt.want)
+		}
+		enc, err := json.Marshal(l)
+		if err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if string(enc) != tt.data {
+			t.Errorf("%s: got %s", tt.data, enc)
+		}
+	}
+
+	var l StringList
+	if err := json.Unmarshal([]byte(`42`), &l); err == nil {
+		t.Error("expected error")
+	}
+}
@@ -20,7 +20,10 @@ import "encoding/json"
 
 type requestContextAlias RequestContext
 
-type authorizer map[string]string
+type authorizer struct {
+	values map[string]string
+	claims bool
+}
 
 // UnmarshalJSON interprets the data as a dynamic map which may carry either a
 // Amazon Cognito set of claims or a custom set of attributes. It then choose
@@ -37,7 +40,7 @@ func (a *authorizer) UnmarshalJSON(data []byte) error {
 	}
 
 	if cognito.Claims != nil {
-		*a = authorizer(*cognito.Claims)
+		a.values, a.claims = *cognito.Claims, true
 		return nil
 	}
 
@@ -46,26 +49,37 @@ func (a *authorizer) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	*a = authorizer(custom)
+	a.values, a.claims = custom, false
 	return nil
 }
 
+// MarshalJSON returns the authorizer values, nested in a claims object when
+// they were issued by Amazon Cognito.
+func (a *authorizer) MarshalJSON() ([]byte, error) {
+	if a.claims {
+		return json.Marshal(map[string]map[string]string{"claims": a.values})
+	}
+	return json.Marshal(a.values)
+}
+
 type jsonRequestContext struct {
 	*requestContextAlias
-	Authorizer authorizer
+	Authorizer *authorizer `json:"authorizer,omitempty"`
 }
 
 // UnmarshalJSON interprets data as a RequestContext with a special authorizer.
 // It then leverages type aliasing and struct embedding to fill RequestContext
 // with an usual map[string]string.
 func (rc *RequestContext) UnmarshalJSON(data []byte) error {
-	var jrc jsonRequestContext
+	jrc := jsonRequestContext{requestContextAlias: (*requestContextAlias)(rc)}
 	if err := json.Unmarshal(data, &jrc); err != nil {
 		return err
 	}
 
-	*rc = *(*RequestContext)(jrc.requestContextAlias)
-	rc.Authorizer = jrc.Authorizer
+	rc.Authorizer, rc.claims = nil, false
+	if jrc.Authorizer != nil {
+		rc.Authorizer, rc.claims = jrc.Authorizer.values, jrc.Authorizer.claims
+	}
 
 	return nil
 }
@@ -73,8 +87,12 @@ func (rc *RequestContext) UnmarshalJSON(data []byte) error {
 // MarshalJSON reverts the effect of type aliasing and struct embedding used
 // during the marshalling step to make the pattern seamless.
 func (rc *RequestContext) MarshalJSON() ([]byte, error) {
+	var a *authorizer
+	if rc.Authorizer != nil {
+		a = &authorizer{rc.Authorizer, rc.claims}
+	}
 	return json.Marshal(&jsonRequestContext{
 		(*requestContextAlias)(rc),
-		rc.Authorizer,
+		a,
 	})
 }
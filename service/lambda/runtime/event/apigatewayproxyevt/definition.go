@@ -21,82 +21,82 @@ import "encoding/json"
 // Identity provides identity information about the API caller.
 type Identity struct {
 	// The API owner key associated with the API.
-	APIKey string
+	APIKey string `json:"apiKey"`
 
 	// The AWS account ID associated with the request.
-	AccountID string
+	AccountID string `json:"accountId"`
 
 	// The User Agent of the API caller.
-	UserAgent string
+	UserAgent string `json:"userAgent"`
 
 	// The source IP address of the TCP connection making the request to
 	// Amazon API Gateway.
-	SourceIP string
+	SourceIP string `json:"sourceIp"`
 
 	// The Amazon Access Key associated with the request.
-	AccessKey string
+	AccessKey string `json:"accessKey"`
 
 	// The principal identifier of the caller making the request.
 	// It is same as the User and interchangeable.
-	Caller string
+	Caller string `json:"caller"`
 
 	// The principal identifier of the user making the request.
 	// It is same as the Caller and interchangeable.
-	User string
+	User string `json:"user"`
 
 	// The Amazon Resource Name (ARN) of the effective user identified after
 	// authentication.
-	UserARN string
+	UserARN string `json:"userArn"`
 
 	// The Amazon Cognito identity ID of the caller making the request.
 	// Available only if the request was signed with Amazon Cognito
 	// credentials.
-	CognitoIdentityID string
+	CognitoIdentityID string `json:"cognitoIdentityId"`
 
 	// The Amazon Cognito identity pool ID of the caller making the request.
 	// Available only if the request was signed with Amazon Cognito
 	// credentials.
-	CognitoIdentityPoolID string
+	CognitoIdentityPoolID string `json:"cognitoIdentityPoolId"`
 
 	// The Amazon Cognito authentication type of the caller making the
 	// request. Available only if the request was signed with Amazon Cognito
 	// credentials.
-	CognitoAuthenticationType string
+	CognitoAuthenticationType string `json:"cognitoAuthenticationType"`
 
 	// The Amazon Cognito authentication provider used by the caller making
 	// the request. Available only if the request was signed with
 	// Amazon Cognito credentials.
-	CognitoAuthenticationProvider string
+	CognitoAuthenticationProvider string `json:"cognitoAuthenticationProvider"`
 }
 
 // RequestContext provides contextual information about an Amazon API Gateway
 // Proxy event.
 type RequestContext struct {
 	// The identifier Amazon API Gateway assigns to the API.
-	APIID string
+	APIID string `json:"apiId"`
 
 	// The identifier Amazon API Gateway assigns to the resource.
-	ResourceID string
+	ResourceID string `json:"resourceId"`
 
 	// An automatically generated ID for the API call.
-	RequestID string
+	RequestID string `json:"requestId"`
 
 	// The incoming request HTTP method name.
 	// Valid values include: DELETE, GET, HEAD, OPTIONS, PATCH, POST, and
 	// PUT.
-	HTTPMethod string
+	HTTPMethod string `json:"httpMethod"`
 
 	// The resource path as defined in Amazon API Gateway.
-	ResourcePath string
+	ResourcePath string `json:"resourcePath"`
 
 	// The AWS account ID associated with the API.
-	AccountID string
+	AccountID string `json:"accountId"`
 
 	// The deployment stage of the API call (for example, Beta or Prod).
-	Stage string
+	Stage string `json:"stage"`
 
 	// The API caller identification information.
-	Identity *Identity
+	Identity *Identity `json:"identity"`
 
 	// If used with Amazon Cognito, it represents the claims returned from
 	// the Amazon Cognito user pool after the method caller is successfully
@@ -105,6 +105,10 @@ type RequestContext struct {
 	// specified key-value pair of the context map returned from the custom
 	// authorizer AWS Lambda function.
 	Authorizer map[string]string `json:"-"`
+
+	// Whether the Authorizer holds Amazon Cognito claims, which are nested
+	// in a claims object on the wire.
+	claims bool
 }
 
 // Event represents an Amazon API Gateway Proxy Event.
@@ -112,43 +116,43 @@ type Event struct {
 	// The incoming request HTTP method name.
 	// Valid values include: DELETE, GET, HEAD, OPTIONS, PATCH, POST, and
 	// PUT.
-	HTTPMethod string
+	HTTPMethod string `json:"httpMethod"`
 
 	// The incoming request HTTP headers.
 	// Duplicate entries are not supported.
-	Headers map[string]string
+	Headers map[string]string `json:"headers"`
 
 	// The resource path with raw placeholders as defined in
 	// Amazon API Gateway.
-	Resource string
+	Resource string `json:"resource"`
 
 	// The incoming request path parameters corresponding to the resource
 	// path placeholders values as defined in Resource.
-	PathParameters map[string]string
+	PathParameters map[string]string `json:"pathParameters"`
 
 	// The real path corresponding to the path parameters injected into the
 	// Resource placeholders.
-	Path string
+	Path string `json:"path"`
 
 	// The incoming request query string parameters.
 	// Duplicate entries are not supported.
-	QueryStringParameters map[string]string
+	QueryStringParameters map[string]string `json:"queryStringParameters"`
 
 	// If used with Amazon API Gateway binary support, it represents the
 	// Base64 encoded binary data from the client.
 	// Otherwise it represents the raw data from the client.
-	Body string
+	Body string `json:"body"`
 
 	// A flag to indicate if the applicable request payload is Base64
 	// encoded.
-	IsBase64Encoded bool
+	IsBase64Encoded bool `json:"isBase64Encoded"`
 
 	// The name-value pairs defined as configuration attributes associated
 	// with the deployment stage of the API.
-	StageVariables map[string]string
+	StageVariables map[string]string `json:"stageVariables"`
 
 	// The contextual information associated with the API call.
-	RequestContext *RequestContext
+	RequestContext *RequestContext `json:"requestContext"`
 }
 
 // Response represents an API Gateway Lambda Proxy response format.
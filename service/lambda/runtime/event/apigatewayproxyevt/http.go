@@ -0,0 +1,200 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package apigatewayproxyevt
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+type contextKey struct{}
+
+// NewContext returns a new Context that carries the given RequestContext.
+func NewContext(ctx context.Context, rc *RequestContext) context.Context {
+	return context.WithValue(ctx, contextKey{}, rc)
+}
+
+// FromContext returns the RequestContext value stored in ctx, if any.
+func FromContext(ctx context.Context) (*RequestContext, bool) {
+	rc, ok := ctx.Value(contextKey{}).(*RequestContext)
+	return rc, ok
+}
+
+// NewRequest converts an Amazon API Gateway Proxy event into an usual
+// *http.Request. The event RequestContext is available through FromContext
+// on the request context.
+func NewRequest(evt *Event) (*http.Request, error) {
+	body := []byte(evt.Body)
+	if evt.IsBase64Encoded {
+		b, err := base64.StdEncoding.DecodeString(evt.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+
+	q := make(url.Values, len(evt.QueryStringParameters))
+	for k, v := range evt.QueryStringParameters {
+		q.Set(k, v)
+	}
+
+	u := &url.URL{
+		Path:     evt.Path,
+		RawQuery: q.Encode(),
+	}
+
+	r, err := http.NewRequest(evt.HTTPMethod, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	r.RequestURI = u.RequestURI()
+
+	for k, v := range evt.Headers {
+		r.Header.Set(k, v)
+	}
+	r.Host = r.Header.Get("Host")
+
+	if evt.RequestContext != nil {
+		if evt.RequestContext.Identity != nil {
+			r.RemoteAddr = evt.RequestContext.Identity.SourceIP
+		}
+		r = r.WithContext(NewContext(r.Context(), evt.RequestContext))
+	}
+
+	return r, nil
+}
+
+// ResponseWriter implements http.ResponseWriter and captures what a
+// http.Handler writes in order to build an Amazon API Gateway Proxy Response.
+type ResponseWriter struct {
+	header      http.Header
+	body        bytes.Buffer
+	status      int
+	wroteHeader bool
+}
+
+// NewResponseWriter returns an initialized ResponseWriter.
+func NewResponseWriter() *ResponseWriter {
+	return &ResponseWriter{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+// Header returns the header map that will be sent by WriteHeader.
+func (w *ResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// Write writes the data to the response body. If WriteHeader has not yet
+// been called, Write calls WriteHeader(http.StatusOK) before writing the data.
+func (w *ResponseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.body.Write(p)
+}
+
+// WriteHeader sets the response status code. Only the first call is
+// effective.
+func (w *ResponseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.status = status
+	w.wroteHeader = true
+}
+
+// Response builds the Amazon API Gateway Proxy Response from what have been
+// written so far. Multiple values of a same header are joined with a comma as
+// duplicate entries are not supported. The body is Base64 encoded and
+// IsBase64Encoded is set when the content is detected as binary.
+func (w *ResponseWriter) Response() *Response {
+	body := w.body.Bytes()
+
+	ct := w.header.Get("Content-Type")
+	if ct == "" && len(body) > 0 {
+		ct = http.DetectContentType(body)
+		w.header.Set("Content-Type", ct)
+	}
+
+	headers := make(map[string]string, len(w.header))
+	for k, v := range w.header {
+		headers[k] = strings.Join(v, ",")
+	}
+
+	resp := &Response{
+		StatusCode: w.status,
+		Headers:    headers,
+	}
+	if isBinary(ct, body) {
+		resp.IsBase64Encoded = true
+		resp.Body = base64.StdEncoding.EncodeToString(body)
+	} else {
+		resp.Body = string(body)
+	}
+
+	return resp
+}
+
+// isBinary reports whether a payload of the given content type must be
+// Base64 encoded to go through Amazon API Gateway.
+func isBinary(ct string, body []byte) bool {
+	if !utf8.Valid(body) {
+		return true
+	}
+
+	mt, _, err := mime.ParseMediaType(ct)
+	if err != nil {
+		return false
+	}
+
+	switch {
+	case strings.HasPrefix(mt, "text/"):
+		return false
+	case strings.HasSuffix(mt, "+json"), strings.HasSuffix(mt, "+xml"):
+		return false
+	}
+
+	switch mt {
+	case "application/json", "application/xml", "application/javascript",
+		"application/x-www-form-urlencoded":
+		return false
+	}
+
+	return true
+}
+
+// Serve dispatches an Amazon API Gateway Proxy event to the given http.Handler
+// and returns the response it wrote.
+func Serve(h http.Handler, evt *Event) (*Response, error) {
+	r, err := NewRequest(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	w := NewResponseWriter()
+	h.ServeHTTP(w, r)
+
+	return w.Response(), nil
+}
@@ -0,0 +1,237 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package apigatewayproxyevt
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestNewRequest(t *testing.T) {
+	evt := &Event{
+		HTTPMethod: "POST",
+		Headers: map[string]string{
+			"Accept":          "*/*",
+			"Content-Type":    "application/octet-stream",
+			"Host":            "1234567890.execute-api.us-east-1.amazonaws.com",
+			"X-Forwarded-For": "54.240.196.186, 54.182.214.83",
+		},
+		Resource:       "/{proxy+}",
+		PathParameters: map[string]string{"proxy": "hello/wörld"},
+		Path:           "/hello/wörld",
+		QueryStringParameters: map[string]string{
+			"name": "me",
+			"q":    "a b&c=d",
+		},
+		Body:            base64.StdEncoding.EncodeToString([]byte{0x89, 'P', 'N', 'G', 0x00}),
+		IsBase64Encoded: true,
+		RequestContext: &RequestContext{
+			AccountID: "123456789012",
+			Stage:     "prod",
+			Identity:  &Identity{SourceIP: "54.240.196.186"},
+		},
+	}
+
+	r, err := NewRequest(evt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Method != "POST" {
+		t.Errorf("got method %s", r.Method)
+	}
+	if r.URL.Path != "/hello/wörld" {
+		t.Errorf("got path %s", r.URL.Path)
+	}
+	if r.RequestURI != "/hello/w%C3%B6rld?name=me&q=a+b%26c%3Dd" {
+		t.Errorf("got request URI %s", r.RequestURI)
+	}
+	if got := r.URL.Query(); got.Get("name") != "me" || got.Get("q") != "a b&c=d" || len(got) != 2 {
+		t.Errorf("got query %v", got)
+	}
+	if r.Header.Get("Content-Type") != "application/octet-stream" || r.Header.Get("X-Forwarded-For") != "54.240.196.186, 54.182.214.83" {
+		t.Errorf("got headers %v", r.Header)
+	}
+	if r.Host != "1234567890.execute-api.us-east-1.amazonaws.com" {
+		t.Errorf("got host %s", r.Host)
+	}
+	if r.RemoteAddr != "54.240.196.186" {
+		t.Errorf("got remote address %s", r.RemoteAddr)
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []byte{0x89, 'P', 'N', 'G', 0x00}; !reflect.DeepEqual(body, want) {
+		t.Errorf("got body %q, want %q", body, want)
+	}
+
+	if rc, ok := FromContext(r.Context()); !ok || rc != evt.RequestContext {
+		t.Errorf("got request context %v, %v", rc, ok)
+	}
+}
+
+func TestNewRequestPlainBody(t *testing.T) {
+	r, err := NewRequest(&Event{HTTPMethod: "PUT", Path: "/pets/1", Body: `{"name":"rex"}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := ioutil.ReadAll(r.Body)
+	if string(body) != `{"name":"rex"}` || r.ContentLength != int64(len(body)) {
+		t.Errorf("got body %q of length %d", body, r.ContentLength)
+	}
+	if _, ok := FromContext(r.Context()); ok {
+		t.Error("unexpected request context")
+	}
+}
+
+func TestNewRequestInvalidBase64(t *testing.T) {
+	if _, err := NewRequest(&Event{HTTPMethod: "POST", Path: "/", Body: "!!", IsBase64Encoded: true}); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestServe(t *testing.T) {
+	png := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0x00, 0xff}
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    *Response
+	}{
+		{
+			name: "text",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte("hello "))
+				w.Write([]byte("world"))
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			want: &Response{
+				StatusCode: http.StatusCreated,
+				Headers:    map[string]string{"Content-Type": "text/plain; charset=utf-8"},
+				Body:       "hello world",
+			},
+		},
+		{
+			name: "multiple header values",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Add("Cache-Control", "no-cache")
+				w.Header().Add("Cache-Control", "no-store")
+				w.Header().Set("Content-Type", "application/vnd.api+json")
+				w.Write([]byte(`{"data":null}`))
+			},
+			want: &Response{
+				StatusCode: http.StatusOK,
+				Headers: map[string]string{
+					"Cache-Control": "no-cache,no-store",
+					"Content-Type":  "application/vnd.api+json",
+				},
+				Body: `{"data":null}`,
+			},
+		},
+		{
+			name: "no content",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			want: &Response{
+				StatusCode: http.StatusNoContent,
+				Headers:    map[string]string{},
+			},
+		},
+		{
+			name: "binary content type",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/pdf")
+				w.Write([]byte("%PDF-1.4"))
+			},
+			want: &Response{
+				StatusCode:      http.StatusOK,
+				Headers:         map[string]string{"Content-Type": "application/pdf"},
+				Body:            base64.StdEncoding.EncodeToString([]byte("%PDF-1.4")),
+				IsBase64Encoded: true,
+			},
+		},
+		{
+			name: "detected binary content",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write(png)
+			},
+			want: &Response{
+				StatusCode:      http.StatusOK,
+				Headers:         map[string]string{"Content-Type": "image/png"},
+				Body:            base64.StdEncoding.EncodeToString(png),
+				IsBase64Encoded: true,
+			},
+		},
+		{
+			name: "invalid UTF-8 text",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.Write([]byte{'a', 0xff})
+			},
+			want: &Response{
+				StatusCode:      http.StatusOK,
+				Headers:         map[string]string{"Content-Type": "text/plain"},
+				Body:            base64.StdEncoding.EncodeToString([]byte{'a', 0xff}),
+				IsBase64Encoded: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Serve(tt.handler, &Event{HTTPMethod: "GET", Path: "/"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsBinary(t *testing.T) {
+	tests := []struct {
+		ct   string
+		want bool
+	}{
+		{"text/html; charset=utf-8", false},
+		{"application/json", false},
+		{"application/hal+json", false},
+		{"application/atom+xml", false},
+		{"application/x-www-form-urlencoded", false},
+		{"application/javascript", false},
+		{"image/png", true},
+		{"application/octet-stream", true},
+		{"application/zip", true},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		if got := isBinary(tt.ct, []byte("abc")); got != tt.want {
+			t.Errorf("isBinary(%q) = %v, want %v", tt.ct, got, tt.want)
+		}
+	}
+}
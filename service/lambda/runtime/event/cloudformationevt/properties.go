@@ -0,0 +1,184 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudformationevt
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DecodeProperties decodes the ResourceProperties of the event into the value
+// pointed to by v.
+//
+// AWS CloudFormation sends every scalar property value as a string. Values
+// are therefore converted back to numbers and booleans according to the type
+// of the destination, as encoding/json would otherwise refuse them.
+func (e *Event) DecodeProperties(v interface{}) error {
+	return decodeProperties(e.ResourceProperties, v)
+}
+
+// DecodeOldProperties decodes the OldResourceProperties of the event into the
+// value pointed to by v, the same way DecodeProperties does. It is a no-op
+// when the event carries no previous properties, which is the case for
+// anything but Update requests.
+func (e *Event) DecodeOldProperties(v interface{}) error {
+	return decodeProperties(e.OldResourceProperties, v)
+}
+
+func decodeProperties(data json.RawMessage, v interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		raw = coerce(raw, rv.Type().Elem())
+	}
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// coerce converts the string values of the generic JSON value x into numbers
+// and booleans where the Go type t expects them.
+func coerce(x interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		return x
+	}
+
+	switch x := x.(type) {
+	case string:
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			if _, err := strconv.ParseFloat(x, 64); err == nil {
+				return json.Number(x)
+			}
+		case reflect.Bool:
+			if b, err := strconv.ParseBool(x); err == nil {
+				return b
+			}
+		}
+	case []interface{}:
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i := range x {
+				x[i] = coerce(x[i], t.Elem())
+			}
+		}
+	case map[string]interface{}:
+		switch t.Kind() {
+		case reflect.Map:
+			for k := range x {
+				x[k] = coerce(x[k], t.Elem())
+			}
+		case reflect.Struct:
+			for k := range x {
+				if ft, ok := fieldType(t, k); ok {
+					x[k] = coerce(x[k], ft)
+				}
+			}
+		}
+	}
+	return x
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+
+// fieldType returns the type of the field of the struct type t which
+// encoding/json would fill with the given key. As encoding/json does, an exact
+// match prevails over a case-insensitive one, and the fields promoted from
+// embedded structs are looked up after the ones of t.
+func fieldType(t reflect.Type, key string) (reflect.Type, bool) {
+	fields := jsonFields(t)
+	for _, f := range fields {
+		if f.name == key {
+			return f.typ, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f.typ, true
+		}
+	}
+	return nil, false
+}
+
+type jsonField struct {
+	name string
+	typ  reflect.Type
+}
+
+// jsonFields returns the fields of the struct type t encoding/json considers,
+// followed by the ones promoted from its embedded structs, level by level.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	visited := make(map[reflect.Type]bool)
+	for level := []reflect.Type{t}; len(level) > 0; {
+		var next []reflect.Type
+		for _, t := range level {
+			if visited[t] {
+				continue
+			}
+			visited[t] = true
+
+			for i := 0; i < t.NumField(); i++ {
+				sf := t.Field(i)
+				name := strings.Split(sf.Tag.Get("json"), ",")[0]
+				if name == "-" {
+					continue
+				}
+
+				if sf.Anonymous && name == "" {
+					ft := sf.Type
+					if ft.Kind() == reflect.Ptr {
+						ft = ft.Elem()
+					}
+					// Unexported embedded structs still promote their
+					// fields, unless they are embedded by pointer.
+					if ft.Kind() == reflect.Struct && (sf.PkgPath == "" || sf.Type.Kind() != reflect.Ptr) {
+						next = append(next, ft)
+						continue
+					}
+				}
+				if sf.PkgPath != "" {
+					continue
+				}
+
+				if name == "" {
+					name = sf.Name
+				}
+				fields = append(fields, jsonField{name, sf.Type})
+			}
+		}
+		level = next
+	}
+	return fields
+}
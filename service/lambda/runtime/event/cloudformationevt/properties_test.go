@@ -0,0 +1,123 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudformationevt
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testBase struct {
+	Port    int
+	Enabled bool `json:"enabled"`
+}
+
+type TimeoutProperties struct {
+	Read  float64
+	Write float64
+}
+
+type testListener struct {
+	Port     int
+	Protocol string
+}
+
+type testProperties struct {
+	testBase
+	*TimeoutProperties
+
+	Name      string
+	Count     int               `json:"count"`
+	Ratio     float32           `json:"ratio"`
+	Public    *bool             `json:"public"`
+	Ports     []uint16          `json:"ports"`
+	Flags     map[string]bool   `json:"flags"`
+	Listeners []*testListener   `json:"listeners"`
+	Tags      map[string]string `json:"tags"`
+	Created   time.Time         `json:"created"`
+	Ignored   int               `json:"-"`
+}
+
+func TestDecodeProperties(t *testing.T) {
+	evt := &Event{ResourceProperties: json.RawMessage(`{
+		"ServiceToken": "arn:aws:lambda:us-east-1:123456789012:function:f",
+		"Port": "8080",
+		"enabled": "true",
+		"Read": "1.5",
+		"Write": "2",
+		"Name": "42",
+		"COUNT": "3",
+		"ratio": "0.25",
+		"public": "false",
+		"ports": ["80", "443"],
+		"flags": {"a": "true", "b": "false"},
+		"listeners": [{"port": "80", "protocol": "HTTP"}],
+		"tags": {"Port": "80"},
+		"created": "2017-03-17T08:00:00Z"
+	}`)}
+
+	var got testProperties
+	if err := evt.DecodeProperties(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	public := false
+	want := testProperties{
+		testBase:          testBase{Port: 8080, Enabled: true},
+		TimeoutProperties: &TimeoutProperties{Read: 1.5, Write: 2},
+		Name:              "42",
+		Count:             3,
+		Ratio:             0.25,
+		Public:            &public,
+		Ports:             []uint16{80, 443},
+		Flags:             map[string]bool{"a": true, "b": false},
+		Listeners:         []*testListener{{Port: 80, Protocol: "HTTP"}},
+		Tags:              map[string]string{"Port": "80"},
+		Created:           time.Date(2017, 3, 17, 8, 0, 0, 0, time.UTC),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodePropertiesInvalid(t *testing.T) {
+	for _, data := range []string{
+		`{"count": "three"}`,
+		`{"public": "maybe"}`,
+		`{"Port": "80.5.1"}`,
+	} {
+		var v testProperties
+		evt := &Event{ResourceProperties: json.RawMessage(data)}
+		if err := evt.DecodeProperties(&v); err == nil {
+			t.Errorf("%s: expected error", data)
+		}
+	}
+}
+
+func TestDecodeOldProperties(t *testing.T) {
+	v := testProperties{Count: 7}
+	if err := (&Event{}).DecodeOldProperties(&v); err != nil || v.Count != 7 {
+		t.Errorf("got %v, %+v", err, v)
+	}
+
+	evt := &Event{OldResourceProperties: json.RawMessage(`{"count": "1"}`)}
+	if err := evt.DecodeOldProperties(&v); err != nil || v.Count != 1 {
+		t.Errorf("got %v, %+v", err, v)
+	}
+}
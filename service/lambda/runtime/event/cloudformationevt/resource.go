@@ -0,0 +1,178 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudformationevt
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// The request types set by the AWS CloudFormation stack operation.
+const (
+	RequestCreate = "Create"
+	RequestUpdate = "Update"
+	RequestDelete = "Delete"
+)
+
+// HandlerFunc handles a custom resource request. It returns the physical ID of
+// the resource and the name-value pairs made available through Fn::GetAtt.
+//
+// An empty physical ID keeps the one sent with the request. Returning a
+// physical ID different from the one of an Update request tells
+// AWS CloudFormation that the resource has been replaced, which will issue a
+// Delete request for the previous one.
+type HandlerFunc func(ctx context.Context, evt *Event) (physicalID string, data map[string]interface{}, err error)
+
+// Resource dispatches AWS CloudFormation custom resource requests to the
+// handler registered for their request type and always answers
+// AWS CloudFormation, so that stack operations never hang waiting for a
+// response.
+type Resource struct {
+	// The handler of Create requests.
+	// If nil, Create requests fail.
+	Create HandlerFunc
+
+	// The handler of Update requests.
+	// If nil, Update requests fail.
+	Update HandlerFunc
+
+	// The handler of Delete requests.
+	// If nil, Delete requests succeed without doing anything.
+	Delete HandlerFunc
+
+	// The sender used to answer AWS CloudFormation.
+	// If nil, DefaultSender is used.
+	Sender *Sender
+
+	// The time reserved before the deadline of the context to send a FAILED
+	// response when the handler has not returned yet.
+	// If zero, DefaultMargin is used.
+	Margin time.Duration
+}
+
+// DefaultMargin is the default time reserved to answer AWS CloudFormation
+// before the deadline of the context.
+const DefaultMargin = 2 * time.Second
+
+// Handle runs the handler registered for the request type of the event and
+// sends a SUCCESS or FAILED response accordingly. A FAILED response is sent as
+// well if the handler panics, or if it has not returned when the deadline of
+// ctx approaches. Handle returns an error only if the response could not be
+// sent.
+func (r *Resource) Handle(ctx context.Context, evt *Event) error {
+	var h HandlerFunc
+	switch evt.RequestType {
+	case RequestCreate:
+		h = r.Create
+	case RequestUpdate:
+		h = r.Update
+	case RequestDelete:
+		h = r.Delete
+		if h == nil {
+			h = noop
+		}
+	}
+
+	resp := NewResponse(evt)
+	if h == nil {
+		r.fail(resp, fmt.Errorf("unsupported request type: %s", evt.RequestType))
+		return r.send(ctx, evt, resp)
+	}
+
+	hctx, cancel := ctx, context.CancelFunc(func() {})
+	if deadline, ok := ctx.Deadline(); ok {
+		hctx, cancel = context.WithDeadline(ctx, deadline.Add(-r.margin()))
+	}
+	defer cancel()
+
+	// The handler may outlive Handle when the deadline approaches, so it
+	// hands its result over instead of sharing variables.
+	results := make(chan result, 1)
+	go func() {
+		var res result
+		defer func() {
+			if v := recover(); v != nil {
+				res = result{err: fmt.Errorf("panic: %v", v)}
+			}
+			results <- res
+		}()
+		res.id, res.data, res.err = h(hctx, evt)
+	}()
+
+	select {
+	case res := <-results:
+		if res.err != nil {
+			r.fail(resp, res.err)
+			break
+		}
+		resp.Status = StatusSuccess
+		resp.Data = res.data
+		if res.id != "" {
+			resp.PhysicalResourceID = res.id
+		}
+	case <-hctx.Done():
+		r.fail(resp, fmt.Errorf("timeout: %v", hctx.Err()))
+	}
+
+	return r.send(ctx, evt, resp)
+}
+
+// WithRemainingTime returns a copy of parent whose deadline is remaining
+// milliseconds from now, typically the value returned by the
+// RemainingTimeInMillis method of the AWS Lambda runtime context, so that
+// Handle answers AWS CloudFormation before the function times out.
+func WithRemainingTime(parent context.Context, remaining int64) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, time.Duration(remaining)*time.Millisecond)
+}
+
+// result holds the return values of a HandlerFunc.
+type result struct {
+	id   string
+	data map[string]interface{}
+	err  error
+}
+
+func (r *Resource) fail(resp *Response, err error) {
+	resp.Status = StatusFailed
+	resp.Reason = err.Error()
+}
+
+// send answers AWS CloudFormation. The request ID is used as physical ID when
+// none is known yet, since AWS CloudFormation rejects empty ones.
+func (r *Resource) send(ctx context.Context, evt *Event, resp *Response) error {
+	if resp.PhysicalResourceID == "" {
+		resp.PhysicalResourceID = evt.RequestID
+	}
+
+	s := r.Sender
+	if s == nil {
+		s = DefaultSender
+	}
+	return s.Send(ctx, evt.ResponseURL, resp)
+}
+
+func (r *Resource) margin() time.Duration {
+	if r.Margin == 0 {
+		return DefaultMargin
+	}
+	return r.Margin
+}
+
+func noop(context.Context, *Event) (string, map[string]interface{}, error) {
+	return "", nil, nil
+}
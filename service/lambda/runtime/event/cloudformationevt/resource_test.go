@@ -0,0 +1,180 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudformationevt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestEvent(url, requestType string) *Event {
+	return &Event{
+		RequestType:        requestType,
+		ResponseURL:        url,
+		StackID:            "arn:aws:cloudformation:us-east-1:123456789012:stack/MyStack/guid",
+		RequestID:          "unique id for this request",
+		LogicalResourceID:  "MyResource",
+		PhysicalResourceID: "MyResource-123",
+		ResourceType:       "Custom::Test",
+	}
+}
+
+// handle runs the resource against a test server and returns the response it
+// sent.
+func handle(t *testing.T, ctx context.Context, r *Resource, requestType string) *Response {
+	srv := newTestServer(200)
+	defer srv.Close()
+
+	evt := newTestEvent(srv.URL, requestType)
+	if requestType == RequestCreate {
+		evt.PhysicalResourceID = ""
+	}
+	r.Sender = &Sender{}
+	if err := r.Handle(ctx, evt); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := srv.attempts(); n != 1 {
+		t.Fatalf("got %d responses, want 1", n)
+	}
+	var resp Response
+	if err := json.Unmarshal(srv.bodies[0], &resp); err != nil {
+		t.Fatal(err)
+	}
+	return &resp
+}
+
+func TestResourceHandle(t *testing.T) {
+	created := func(ctx context.Context, evt *Event) (string, map[string]interface{}, error) {
+		return "MyResource-456", map[string]interface{}{"Arn": "arn:test"}, nil
+	}
+	failed := func(ctx context.Context, evt *Event) (string, map[string]interface{}, error) {
+		return "", nil, errors.New("quota exceeded")
+	}
+	panicked := func(ctx context.Context, evt *Event) (string, map[string]interface{}, error) {
+		panic("boom")
+	}
+
+	tests := []struct {
+		name        string
+		resource    *Resource
+		requestType string
+		status      string
+		reason      string
+		physicalID  string
+	}{
+		{"create", &Resource{Create: created}, RequestCreate, StatusSuccess, "", "MyResource-456"},
+		{"create failure", &Resource{Create: failed}, RequestCreate, StatusFailed, "quota exceeded", "unique id for this request"},
+		{"create panic", &Resource{Create: panicked}, RequestCreate, StatusFailed, "panic: boom", "unique id for this request"},
+		{"create without handler", &Resource{}, RequestCreate, StatusFailed, "unsupported request type: Create", "unique id for this request"},
+		{"update replacement", &Resource{Update: created}, RequestUpdate, StatusSuccess, "", "MyResource-456"},
+		{"update failure", &Resource{Update: failed}, RequestUpdate, StatusFailed, "quota exceeded", "MyResource-123"},
+		{"update without handler", &Resource{Create: created}, RequestUpdate, StatusFailed, "unsupported request type: Update", "MyResource-123"},
+		{"delete without handler", &Resource{}, RequestDelete, StatusSuccess, "", "MyResource-123"},
+		{"delete panic", &Resource{Delete: panicked}, RequestDelete, StatusFailed, "panic: boom", "MyResource-123"},
+		{"unknown request type", &Resource{Create: created}, "Rollback", StatusFailed, "unsupported request type: Rollback", "MyResource-123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := handle(t, context.Background(), tt.resource, tt.requestType)
+			if resp.Status != tt.status || resp.Reason != tt.reason || resp.PhysicalResourceID != tt.physicalID {
+				t.Errorf("got %s", resp)
+			}
+			if resp.StackID == "" || resp.RequestID == "" || resp.LogicalResourceID != "MyResource" {
+				t.Errorf("missing request values in %s", resp)
+			}
+			if tt.status == StatusSuccess && tt.physicalID == "MyResource-456" && resp.Data["Arn"] != "arn:test" {
+				t.Errorf("missing data in %s", resp)
+			}
+		})
+	}
+}
+
+func TestResourceHandleDeadline(t *testing.T) {
+	tests := []struct {
+		name string
+		// wait blocks the handler until it is released.
+		wait func(ctx context.Context, release <-chan struct{})
+	}{
+		{"handler honoring its context", func(ctx context.Context, release <-chan struct{}) {
+			<-ctx.Done()
+			<-release
+		}},
+		{"handler ignoring its context", func(ctx context.Context, release <-chan struct{}) {
+			<-release
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release, returned := make(chan struct{}), make(chan struct{})
+			var hdeadline time.Time
+			r := &Resource{
+				Create: func(ctx context.Context, evt *Event) (string, map[string]interface{}, error) {
+					defer close(returned)
+					hdeadline, _ = ctx.Deadline()
+					tt.wait(ctx, release)
+					return "too-late", map[string]interface{}{"Late": true}, nil
+				},
+				Margin: time.Second,
+			}
+
+			// The margin leaves a whole second to answer before the deadline,
+			// so that a slow machine does not fail the test.
+			ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+			defer cancel()
+			resp := handle(t, ctx, r, RequestCreate)
+			if ctx.Err() != nil {
+				t.Error("response sent after the deadline, want it before")
+			}
+			if resp.Status != StatusFailed || !strings.HasPrefix(resp.Reason, "timeout: ") || resp.Data != nil {
+				t.Errorf("got %s", resp)
+			}
+
+			// The handler returns after Handle, which must not be affected.
+			close(release)
+			<-returned
+			if deadline, _ := ctx.Deadline(); !hdeadline.Equal(deadline.Add(-r.Margin)) {
+				t.Errorf("got handler deadline %v, want %v", hdeadline, deadline.Add(-r.Margin))
+			}
+		})
+	}
+}
+
+func TestWithRemainingTime(t *testing.T) {
+	start := time.Now()
+	ctx, cancel := WithRemainingTime(context.Background(), 3000)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("got no deadline")
+	}
+	if d := deadline.Sub(start); d < 3*time.Second || d > 3*time.Second+time.Since(start) {
+		t.Errorf("got deadline in %v, want 3s", d)
+	}
+
+	cancel()
+	if ctx.Err() != context.Canceled {
+		t.Errorf("got %v after cancel", ctx.Err())
+	}
+}
@@ -0,0 +1,178 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudformationevt
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// The status of a custom resource response.
+const (
+	StatusSuccess = "SUCCESS"
+	StatusFailed  = "FAILED"
+)
+
+// Response represents a custom resource provider response to an
+// AWS CloudFormation event.
+// See http://docs.aws.amazon.com/AWSCloudFormation/latest/UserGuide/crpg-ref-responses.html
+type Response struct {
+	// The status value sent by the custom resource provider in response to
+	// an AWS CloudFormation-generated request. Must be either SUCCESS or
+	// FAILED.
+	Status string `json:"Status"`
+
+	// Describes the reason for a failure response.
+	// Required if Status is FAILED. It's optional otherwise.
+	Reason string `json:"Reason,omitempty"`
+
+	// This value should be an identifier unique to the custom resource
+	// vendor, and can be up to 1 Kb in size. The value must be a non-empty
+	// string and must be identical for all responses for the same resource.
+	PhysicalResourceID string `json:"PhysicalResourceId"`
+
+	// The Amazon Resource Name (ARN) that identifies the stack that contains
+	// the custom resource. This response value should be copied verbatim
+	// from the request.
+	StackID string `json:"StackId"`
+
+	// A unique ID for the request. This response value should be copied
+	// verbatim from the request.
+	RequestID string `json:"RequestId"`
+
+	// The template developer-chosen name (logical ID) of the custom resource
+	// in the AWS CloudFormation template. This response value should be
+	// copied verbatim from the request.
+	LogicalResourceID string `json:"LogicalResourceId"`
+
+	// Indicates whether to mask the output of the custom resource when
+	// retrieved by using the Fn::GetAtt function.
+	NoEcho bool `json:"NoEcho,omitempty"`
+
+	// The custom resource provider-defined name-value pairs to send with the
+	// response. You can access the values provided here by name in the
+	// template with Fn::GetAtt.
+	Data map[string]interface{} `json:"Data,omitempty"`
+}
+
+// NewResponse returns a Response to the given event with the values which must
+// be copied verbatim from the request already filled in. The
+// PhysicalResourceID is copied from the request as well, it must be set for
+// Create requests.
+func NewResponse(evt *Event) *Response {
+	return &Response{
+		PhysicalResourceID: evt.PhysicalResourceID,
+		StackID:            evt.StackID,
+		RequestID:          evt.RequestID,
+		LogicalResourceID:  evt.LogicalResourceID,
+	}
+}
+
+// String returns the string representation.
+func (r *Response) String() string {
+	s, _ := json.Marshal(r)
+	return string(s)
+}
+
+// GoString returns the string representation.
+func (r *Response) GoString() string {
+	return r.String()
+}
+
+// Sender sends custom resource responses to the pre-signed Amazon S3 URL
+// provided by AWS CloudFormation.
+type Sender struct {
+	// The HTTP client used to send the response.
+	// If nil, http.DefaultClient is used.
+	Client *http.Client
+
+	// The number of times a failed attempt is retried. Network errors and
+	// server errors (5xx) are retried, client errors (4xx) are not.
+	Retries int
+
+	// The delay before the first retry, doubled after each attempt.
+	Backoff time.Duration
+}
+
+// DefaultSender is the Sender used by Send.
+var DefaultSender = &Sender{
+	Retries: 3,
+	Backoff: 500 * time.Millisecond,
+}
+
+// Send sends the response to the ResponseURL of the event using DefaultSender.
+func Send(ctx context.Context, evt *Event, r *Response) error {
+	return DefaultSender.Send(ctx, evt.ResponseURL, r)
+}
+
+// Send PUTs the response to the given pre-signed Amazon S3 URL. The request is
+// sent with an empty Content-Type as required by the signature.
+func (s *Sender) Send(ctx context.Context, url string, r *Response) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	backoff := s.Backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := s.put(ctx, client, url, body)
+		if err == nil || !retry || attempt >= s.Retries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// put sends the body once and reports whether a failure may be retried.
+func (s *Sender) put(ctx context.Context, client *http.Client, url string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "")
+	req.ContentLength = int64(len(body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		return resp.StatusCode >= 500, fmt.Errorf("cloudformationevt: unexpected response status: %s", resp.Status)
+	}
+	return false, nil
+}
@@ -0,0 +1,204 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudformationevt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testServer responds to the n-th request with the n-th status, or with the
+// last one once they are exhausted.
+type testServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	statuses []int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newTestServer(statuses ...int) *testServer {
+	s := &testServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, body)
+		status := s.statuses[len(s.statuses)-1]
+		if n := len(s.requests); n <= len(s.statuses) {
+			status = s.statuses[n-1]
+		}
+		w.WriteHeader(status)
+	}))
+	return s
+}
+
+func (s *testServer) attempts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func newTestResponse() *Response {
+	r := NewResponse(&Event{
+		StackID:           "arn:aws:cloudformation:us-east-1:123456789012:stack/MyStack/guid",
+		RequestID:         "unique id for this create request",
+		LogicalResourceID: "MySeleniumTester",
+	})
+	r.Status = StatusSuccess
+	r.PhysicalResourceID = "Tester1"
+	r.Data = map[string]interface{}{"SecretKey": "xyz"}
+	return r
+}
+
+func TestSend(t *testing.T) {
+	srv := newTestServer(http.StatusOK)
+	defer srv.Close()
+
+	r := newTestResponse()
+	s := &Sender{}
+	if err := s.Send(context.Background(), srv.URL+"/presigned?X-Amz-Signature=abc", r); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := srv.attempts(); n != 1 {
+		t.Fatalf("got %d attempts, want 1", n)
+	}
+	req := srv.requests[0]
+	if req.Method != http.MethodPut {
+		t.Errorf("got method %s, want PUT", req.Method)
+	}
+	if req.URL.RawQuery != "X-Amz-Signature=abc" {
+		t.Errorf("got query %s", req.URL.RawQuery)
+	}
+	if ct, ok := req.Header["Content-Type"]; !ok || len(ct) != 1 || ct[0] != "" {
+		t.Errorf("got Content-Type %q, want an empty one", ct)
+	}
+	if req.ContentLength != int64(len(srv.bodies[0])) {
+		t.Errorf("got Content-Length %d, want %d", req.ContentLength, len(srv.bodies[0]))
+	}
+
+	var got Response
+	if err := json.Unmarshal(srv.bodies[0], &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&got, r) {
+		t.Errorf("got body %s, want %s", &got, r)
+	}
+}
+
+func TestSendStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int
+		retries  int
+		attempts int
+		ok       bool
+	}{
+		{"success after server errors", []int{500, 503, 200}, 3, 3, true},
+		{"server errors exhaust retries", []int{502}, 2, 3, false},
+		{"no retry", []int{500}, 0, 1, false},
+		{"client error", []int{403, 200}, 3, 1, false},
+		{"not found", []int{404, 200}, 3, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := newTestServer(tt.statuses...)
+			defer srv.Close()
+
+			s := &Sender{Retries: tt.retries, Backoff: time.Millisecond}
+			err := s.Send(context.Background(), srv.URL, newTestResponse())
+			if (err == nil) != tt.ok {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if n := srv.attempts(); n != tt.attempts {
+				t.Errorf("got %d attempts, want %d", n, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestSendTransportError(t *testing.T) {
+	srv := newTestServer(http.StatusOK)
+	defer srv.Close()
+
+	// The transport fails the first attempts, then reaches the server.
+	var calls, failures int
+	client := &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if calls++; calls <= failures {
+			return nil, errors.New("connection reset by peer")
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})}
+	s := &Sender{Client: client, Retries: 3, Backoff: time.Millisecond}
+
+	calls, failures = 0, 2
+	if err := s.Send(context.Background(), srv.URL, newTestResponse()); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 || srv.attempts() != 1 {
+		t.Errorf("got %d calls and %d attempts, want 3 and 1", calls, srv.attempts())
+	}
+
+	calls, failures = 0, 5
+	if err := s.Send(context.Background(), srv.URL, newTestResponse()); err == nil {
+		t.Error("expected error once retries are exhausted")
+	}
+	if calls != 4 {
+		t.Errorf("got %d calls, want 4", calls)
+	}
+}
+
+func TestSendCanceledDuringBackoff(t *testing.T) {
+	srv := newTestServer(http.StatusServiceUnavailable)
+	defer srv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	s := &Sender{Retries: 3, Backoff: time.Hour}
+	start := time.Now()
+	err := s.Send(ctx, srv.URL, newTestResponse())
+	if err != context.DeadlineExceeded {
+		t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("Send returned after %v", d)
+	}
+	if n := srv.attempts(); n != 1 {
+		t.Errorf("got %d attempts, want 1", n)
+	}
+}
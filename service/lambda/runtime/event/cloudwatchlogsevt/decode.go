@@ -0,0 +1,48 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudwatchlogsevt
+
+import "encoding/json"
+
+// Decoder decodes Amazon CloudWatch Logs events with options json.Unmarshal
+// does not provide.
+type Decoder struct {
+	// SkipControl drops the records of control messages, so that only
+	// actual log data is decoded.
+	SkipControl bool
+
+	// MaxSize is the maximum size, in bytes, of the decompressed data of
+	// an event, to guard against decompression bombs.
+	// If zero, DefaultMaxSize is used.
+	MaxSize int64
+}
+
+// Decode decodes the raw JSON Amazon CloudWatch Logs event.
+func (d *Decoder) Decode(data []byte) (*Event, error) {
+	var raw struct {
+		Records json.RawMessage `json:"awslogs"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	evt := &Event{}
+	if err := evt.Records.decode(raw.Records, d.SkipControl, d.maxSize()); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
@@ -0,0 +1,92 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudwatchlogsevt
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+func TestDecoder(t *testing.T) {
+	tests := []struct {
+		file        string
+		skipControl bool
+		records     int
+		control     bool
+	}{
+		{"testdata/event-control.json", false, 1, true},
+		{"testdata/event-control.json", true, 0, false},
+		{"testdata/event-subscription.json", false, 2, false},
+		{"testdata/event-subscription.json", true, 2, false},
+	}
+
+	for _, tt := range tests {
+		data, err := ioutil.ReadFile(tt.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		evt, err := (&Decoder{SkipControl: tt.skipControl}).Decode(data)
+		if err != nil {
+			t.Errorf("%s: %v", tt.file, err)
+			continue
+		}
+		if len(evt.Records) != tt.records {
+			t.Errorf("%s: got %d records, want %d", tt.file, len(evt.Records), tt.records)
+		}
+		for _, rec := range evt.Records {
+			if rec.IsControl() != tt.control {
+				t.Errorf("%s: got control %t for %s", tt.file, rec.IsControl(), rec)
+			}
+		}
+	}
+}
+
+func TestControlMessage(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/event-control.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var evt Event
+	if err := json.Unmarshal(data, &evt); err != nil {
+		t.Fatal(err)
+	}
+	if len(evt.Records) != 1 {
+		t.Fatalf("got %d records, want 1", len(evt.Records))
+	}
+	rec := evt.Records[0]
+	if !rec.IsControl() || rec.MessageType != ControlMessage || rec.Owner != "CloudwatchLogs" {
+		t.Errorf("got %s", rec)
+	}
+	if want := "CWL CONTROL MESSAGE: Checking health of destination Kinesis stream."; rec.LogEvent.Message != want {
+		t.Errorf("got message %q, want %q", rec.LogEvent.Message, want)
+	}
+}
+
+func TestDecoderInvalid(t *testing.T) {
+	for _, data := range []string{
+		`{"awslogs":"data"}`,
+		`{"awslogs":{"data":"bm90IGd6aXBwZWQ="}}`,
+		`[]`,
+	} {
+		if _, err := (&Decoder{SkipControl: true}).Decode([]byte(data)); err == nil {
+			t.Errorf("%s: got no error", data)
+		}
+	}
+}
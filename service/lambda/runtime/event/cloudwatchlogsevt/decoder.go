@@ -20,8 +20,10 @@ import (
 	"bytes"
 	"compress/gzip"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -40,25 +42,31 @@ func (t *timestamp) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	t.Time = time.Unix(0, v*int64(time.Millisecond))
+	t.Time = time.Unix(v/1e3, v%1e3*int64(time.Millisecond))
 	return nil
 }
 
+// MarshalJSON returns t as the number of milliseconds elapsed since January 1,
+// 1970 00:00:00 UTC.
+func (t timestamp) MarshalJSON() ([]byte, error) {
+	v := t.Unix()*1e3 + int64(t.Nanosecond())/int64(time.Millisecond)
+	return []byte(strconv.FormatInt(v, 10)), nil
+}
+
 type jsonLogEvent struct {
 	*logEventAlias
-	Timestamp timestamp
+	Timestamp timestamp `json:"timestamp"`
 }
 
 // UnmarshalJSON interprets data as a LogEvent with a special timestamp. It then
 // leverages type aliasing and struct embedding to fill LogEvent with an usual
 // time.Time.
 func (le *LogEvent) UnmarshalJSON(data []byte) error {
-	var jle jsonLogEvent
+	jle := jsonLogEvent{logEventAlias: (*logEventAlias)(le)}
 	if err := json.Unmarshal(data, &jle); err != nil {
 		return err
 	}
 
-	*le = *(*LogEvent)(jle.logEventAlias)
 	le.Timestamp = jle.Timestamp.Time
 
 	return nil
@@ -76,8 +84,12 @@ func (le *LogEvent) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON interprets data as an map[string][]byte and ungzip the event
 // data to an EventRecord. For constistency with other AWS Lambda events a list
 // of EventRecords is built with contextual information and the actual log
-// event in it.
+// event in it. The decompressed data is limited to DefaultMaxSize.
 func (recs *EventRecords) UnmarshalJSON(data []byte) error {
+	return recs.decode(data, false, DefaultMaxSize)
+}
+
+func (recs *EventRecords) decode(data []byte, skipControl bool, maxSize int64) error {
 	var logs struct {
 		Data []byte
 	}
@@ -93,31 +105,119 @@ func (recs *EventRecords) UnmarshalJSON(data []byte) error {
 	}
 	defer r.Close()
 
-	s, err := ioutil.ReadAll(r)
+	s, err := ioutil.ReadAll(&limitedReader{r: r, n: maxSize})
 	if err != nil {
 		return err
 	}
 
-	var aux struct {
-		*EventRecord
-		LogEvents []*LogEvent
-	}
+	var aux payload
 	err = json.Unmarshal(s, &aux)
 	if err != nil {
 		return err
 	}
 
-	*recs = make(EventRecords, len(aux.LogEvents))
-	for i, evt := range aux.LogEvents {
-		(*recs)[i] = &EventRecord{
+	*recs = make(EventRecords, 0, len(aux.LogEvents))
+	if skipControl && aux.MessageType == ControlMessage {
+		return nil
+	}
+	for _, evt := range aux.LogEvents {
+		*recs = append(*recs, &EventRecord{
 			Owner:               aux.Owner,
 			LogGroup:            aux.LogGroup,
 			LogStream:           aux.LogStream,
 			LogEvent:            evt,
 			MessageType:         aux.MessageType,
 			SubscriptionFilters: aux.SubscriptionFilters,
-		}
+		})
 	}
 
 	return nil
 }
+
+// payload represents the decompressed data of an Amazon CloudWatch Logs
+// subscription.
+type payload struct {
+	MessageType         MessageType `json:"messageType"`
+	Owner               string      `json:"owner"`
+	LogGroup            string      `json:"logGroup"`
+	LogStream           string      `json:"logStream"`
+	SubscriptionFilters []string    `json:"subscriptionFilters"`
+	LogEvents           []*LogEvent `json:"logEvents"`
+}
+
+// MarshalJSON reverts the effect of EventRecords.UnmarshalJSON. The log events
+// are gathered with their contextual information, gzipped and base64 encoded
+// as Amazon CloudWatch Logs does for subscriptions. Since a subscription
+// payload holds the log events of a single log stream, the records must all
+// share the same contextual information. Use Split to group them beforehand.
+func (e *Event) MarshalJSON() ([]byte, error) {
+	logs, err := e.Records.encode()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		Logs json.RawMessage `json:"awslogs"`
+	}{logs})
+}
+
+func (recs EventRecords) encode() ([]byte, error) {
+	if len(recs.Split()) > 1 {
+		return nil, errors.New("cloudwatchlogsevt: records span several log streams")
+	}
+
+	p := payload{LogEvents: make([]*LogEvent, 0, len(recs))}
+	for _, rec := range recs {
+		p.MessageType = rec.MessageType
+		p.Owner = rec.Owner
+		p.LogGroup = rec.LogGroup
+		p.LogStream = rec.LogStream
+		p.SubscriptionFilters = rec.SubscriptionFilters
+		if rec.LogEvent != nil {
+			p.LogEvents = append(p.LogEvents, rec.LogEvent)
+		}
+	}
+
+	s, err := json.Marshal(&p)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(s); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(struct {
+		Data []byte `json:"data"`
+	}{buf.Bytes()})
+}
+
+// Split groups the records by owner, log group and log stream, as well as by
+// message type and subscription filters, preserving their order. Each group
+// can be marshalled to a single subscription payload.
+func (recs EventRecords) Split() []EventRecords {
+	var groups []EventRecords
+	index := make(map[string]int)
+	for _, rec := range recs {
+		k := strings.Join([]string{
+			rec.Owner,
+			rec.LogGroup,
+			rec.LogStream,
+			string(rec.MessageType),
+			strings.Join(rec.SubscriptionFilters, "\x00"),
+		}, "\x00\x00")
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], rec)
+	}
+	return groups
+}
@@ -0,0 +1,111 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudwatchlogsevt
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		data string
+		want time.Time
+	}{
+		{"1440442987000", time.Unix(1440442987, 0)},
+		{"1440442987001", time.Unix(1440442987, 1e6)},
+		{"1495072949453", time.Unix(1495072949, 453e6)},
+		{"0", time.Unix(0, 0)},
+		{"-1500", time.Unix(-1, -5e8)},
+	}
+
+	for _, tt := range tests {
+		var ts timestamp
+		if err := json.Unmarshal([]byte(tt.data), &ts); err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if !ts.Equal(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.data, ts.Time, tt.want)
+		}
+
+		enc, err := json.Marshal(ts)
+		if err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if string(enc) != tt.data {
+			t.Errorf("%s: got %s", tt.data, enc)
+		}
+	}
+}
+
+func TestTimestampInvalid(t *testing.T) {
+	for _, data := range []string{`"1440442987000"`, `1440442987.5`, `null`} {
+		var ts timestamp
+		if err := json.Unmarshal([]byte(data), &ts); err == nil {
+			t.Errorf("%s: expected error", data)
+		}
+	}
+}
+
+func TestEventMarshalJSON(t *testing.T) {
+	recs := EventRecords{
+		{Owner: "123456789123", LogGroup: "testLogGroup", LogStream: "a", MessageType: DataMessage, LogEvent: &LogEvent{ID: "1", Timestamp: time.Unix(1440442987, 0), Message: "first"}},
+		{Owner: "123456789123", LogGroup: "testLogGroup", LogStream: "a", MessageType: DataMessage, LogEvent: &LogEvent{ID: "2", Timestamp: time.Unix(1440442987, 1e6), Message: "second"}},
+		{Owner: "123456789123", LogGroup: "testLogGroup", LogStream: "b", MessageType: DataMessage, LogEvent: &LogEvent{ID: "3", Timestamp: time.Unix(1440442987, 2e6), Message: "third"}},
+	}
+
+	data, err := json.Marshal(&Event{Records: recs[:2]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), `{"awslogs":{"data":"H4sI`) {
+		t.Errorf("unexpected encoding: %s", data)
+	}
+
+	if _, err := json.Marshal(&Event{Records: recs}); err == nil {
+		t.Error("expected error for records of several log streams")
+	}
+
+	groups := recs.Split()
+	if len(groups) != 2 || len(groups[0]) != 2 || len(groups[1]) != 1 {
+		t.Fatalf("unexpected groups: %v", groups)
+	}
+	for _, g := range groups {
+		if _, err := json.Marshal(&Event{Records: g}); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestEventString(t *testing.T) {
+	evt := &Event{Records: EventRecords{
+		{Owner: "123456789123", LogGroup: "testLogGroup", LogStream: "a", LogEvent: &LogEvent{ID: "1", Timestamp: time.Unix(1440442987, 0), Message: "first"}},
+		{Owner: "123456789123", LogGroup: "testLogGroup", LogStream: "b", LogEvent: &LogEvent{ID: "2", Timestamp: time.Unix(1440442987, 1e6), Message: "second"}},
+	}}
+
+	for _, s := range []string{evt.String(), evt.GoString()} {
+		for _, w := range []string{`{"awslogs":[{"owner":"123456789123"`, `"logStream":"b"`, `"timestamp":1440442987001`, `"message":"second"`} {
+			if !strings.Contains(s, w) {
+				t.Errorf("missing %s in %s", w, s)
+			}
+		}
+	}
+}
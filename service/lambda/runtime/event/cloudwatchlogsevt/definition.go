@@ -21,42 +21,60 @@ import (
 	"time"
 )
 
+// MessageType is the type of an Amazon CloudWatch Logs subscription message.
+type MessageType string
+
+// The types of Amazon CloudWatch Logs subscription messages.
+const (
+	// The usual message type, for log data.
+	DataMessage MessageType = "DATA_MESSAGE"
+
+	// The message type Amazon CloudWatch Logs uses to check that the
+	// destination of a subscription is reachable.
+	ControlMessage MessageType = "CONTROL_MESSAGE"
+)
+
 // LogEvent represents a log event, which is a record of activity that was
 // recorded by the application or resource being monitored.
 type LogEvent struct {
 	// A unique identifier for every log event.
-	ID string
+	ID string `json:"id"`
 
 	// The time the event occurred.
 	Timestamp time.Time `json:"-"`
 
 	// The data contained in the log event.
-	Message string
+	Message string `json:"message"`
 }
 
 // EventRecord provides contextual information about an Amazon CloudWatch Logs
 // event.
 type EventRecord struct {
 	// The AWS Account ID of the originating log data.
-	Owner string
+	Owner string `json:"owner"`
 
 	// The log group name of the originating log data.
-	LogGroup string
+	LogGroup string `json:"logGroup"`
 
 	// The log stream name of the originating log data.
-	LogStream string
+	LogStream string `json:"logStream"`
 
 	// The actual log data.
-	LogEvent *LogEvent
+	LogEvent *LogEvent `json:"logEvent"`
 
-	// Message type can be one of:
-	// - DATA_MESSAGE: Usual message type for payload.
-	// - CONTROL_MESSAGE: Excpetional message type for ping.
-	MessageType string
+	// The message type, either DataMessage or ControlMessage.
+	MessageType MessageType `json:"messageType"`
 
 	// The list of subscription filter names that matched with the originating log
 	// data.
-	SubscriptionFilters []string
+	SubscriptionFilters []string `json:"subscriptionFilters"`
+}
+
+// IsControl reports whether the record comes from a control message, sent by
+// Amazon CloudWatch Logs to check the subscription, rather than from actual
+// log data.
+func (e *EventRecord) IsControl() bool {
+	return e.MessageType == ControlMessage
 }
 
 // String returns the string representation.
@@ -79,9 +97,12 @@ type Event struct {
 	Records EventRecords `json:"awslogs"`
 }
 
-// String returns the string representation.
+// String returns the string representation. Unlike MarshalJSON, the records
+// are left as is to remain readable.
 func (e *Event) String() string {
-	s, _ := json.Marshal(e)
+	s, _ := json.Marshal(struct {
+		Records []*EventRecord `json:"awslogs"`
+	}{e.Records})
 	return string(s)
 }
 
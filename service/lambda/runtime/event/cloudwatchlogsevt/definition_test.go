@@ -0,0 +1,95 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudwatchlogsevt
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/internal/eventtest"
+)
+
+func TestLogEventRoundTrip(t *testing.T) {
+	eventtest.RoundTrip(t, "testdata/logevent-*.json", func() interface{} { return new(LogEvent) })
+}
+
+// TestEventRoundTrip compares the decompressed subscription payloads, since
+// gzip streams of the same data may differ from one encoder to another.
+func TestEventRoundTrip(t *testing.T) {
+	files, err := filepath.Glob("testdata/event-*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no payload")
+	}
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var evt Event
+		if err := json.Unmarshal(data, &evt); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		out, err := json.Marshal(&evt)
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		if err := eventtest.Equal(subscriptionPayload(t, out), subscriptionPayload(t, data)); err != nil {
+			t.Errorf("%s: %v", file, err)
+		}
+
+		var evt2 Event
+		if err := json.Unmarshal(out, &evt2); err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		if !reflect.DeepEqual(&evt2, &evt) {
+			t.Errorf("%s: got %v, want %v", file, &evt2, &evt)
+		}
+	}
+}
+
+// subscriptionPayload returns the decompressed data of an Amazon CloudWatch
+// Logs subscription event.
+func subscriptionPayload(t *testing.T, data []byte) []byte {
+	var evt struct {
+		Logs struct {
+			Data []byte `json:"data"`
+		} `json:"awslogs"`
+	}
+	if err := json.Unmarshal(data, &evt); err != nil {
+		t.Fatal(err)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(evt.Logs.Data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	p, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
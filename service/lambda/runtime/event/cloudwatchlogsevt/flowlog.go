@@ -0,0 +1,256 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudwatchlogsevt
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The actions of a VPC flow log record.
+const (
+	FlowLogAccept = "ACCEPT"
+	FlowLogReject = "REJECT"
+)
+
+// The logging statuses of a VPC flow log record.
+const (
+	FlowLogOK       = "OK"
+	FlowLogNoData   = "NODATA"
+	FlowLogSkipData = "SKIPDATA"
+)
+
+// FlowLogRecord represents a VPC flow log record, that is a network flow in a
+// VPC during an aggregation interval. The fields missing from the format of
+// the record, or not applicable to it, are left to their zero value.
+// See also http://docs.aws.amazon.com/vpc/latest/userguide/flow-logs.html#flow-log-records
+type FlowLogRecord struct {
+	// The VPC flow logs version.
+	Version int
+
+	// The AWS account ID of the owner of the source network interface.
+	AccountID string
+
+	// The ID of the network interface for which the traffic is recorded.
+	InterfaceID string
+
+	// The source address for incoming traffic, or the IPv4 or IPv6 address
+	// of the network interface for outgoing traffic.
+	SrcAddr net.IP
+
+	// The destination address for outgoing traffic, or the IPv4 or IPv6
+	// address of the network interface for incoming traffic.
+	DstAddr net.IP
+
+	// The source port of the traffic.
+	SrcPort int
+
+	// The destination port of the traffic.
+	DstPort int
+
+	// The IANA protocol number of the traffic.
+	Protocol int
+
+	// The number of packets transferred during the flow.
+	Packets int64
+
+	// The number of bytes transferred during the flow.
+	Bytes int64
+
+	// The time the first packet of the flow was received within the
+	// aggregation interval.
+	Start time.Time
+
+	// The time the last packet of the flow was received within the
+	// aggregation interval.
+	End time.Time
+
+	// The action associated with the traffic, either FlowLogAccept or
+	// FlowLogReject.
+	Action string
+
+	// The logging status of the flow log, one of FlowLogOK, FlowLogNoData
+	// or FlowLogSkipData.
+	LogStatus string
+
+	// The ID of the VPC of the network interface. Since version 3.
+	VPCID string
+
+	// The ID of the subnet of the network interface. Since version 3.
+	SubnetID string
+
+	// The ID of the instance associated with the network interface, if
+	// owned by the account. Since version 3.
+	InstanceID string
+
+	// The bitmask of the TCP flags seen during the flow. Since version 3.
+	TCPFlags int
+
+	// The type of traffic: IPv4, IPv6 or EFA. Since version 3.
+	Type string
+
+	// The packet-level source address of the traffic. Since version 3.
+	PktSrcAddr net.IP
+
+	// The packet-level destination address of the traffic. Since version 3.
+	PktDstAddr net.IP
+
+	// The region of the network interface. Since version 4.
+	Region string
+
+	// The ID of the availability zone of the network interface. Since
+	// version 4.
+	AZID string
+
+	// The type of sublocation of the network interface: wavelength,
+	// outpost or localzone. Since version 4.
+	SublocationType string
+
+	// The ID of the sublocation of the network interface. Since version 4.
+	SublocationID string
+
+	// The name of the AWS service the source address belongs to. Since
+	// version 5.
+	PktSrcAWSService string
+
+	// The name of the AWS service the destination address belongs to.
+	// Since version 5.
+	PktDstAWSService string
+
+	// The direction of the flow with respect to the network interface,
+	// either ingress or egress. Since version 5.
+	FlowDirection string
+
+	// The path egress traffic takes to the destination. Since version 5.
+	TrafficPath int
+}
+
+// FlowLogFormat is the ordered list of the fields of the VPC flow log records,
+// as named in the flow log format, without the ${} delimiters.
+type FlowLogFormat []string
+
+// DefaultFlowLogFormat is the format of the VPC flow log records when no
+// custom format is specified.
+var DefaultFlowLogFormat = FlowLogFormat{
+	"version", "account-id", "interface-id", "srcaddr", "dstaddr", "srcport",
+	"dstport", "protocol", "packets", "bytes", "start", "end", "action",
+	"log-status",
+}
+
+// flowLogFields sets the field of a record from its textual value.
+var flowLogFields = map[string]func(r *FlowLogRecord, v string) error{
+	"version":             func(r *FlowLogRecord, v string) (err error) { r.Version, err = strconv.Atoi(v); return },
+	"account-id":          func(r *FlowLogRecord, v string) error { r.AccountID = v; return nil },
+	"interface-id":        func(r *FlowLogRecord, v string) error { r.InterfaceID = v; return nil },
+	"srcaddr":             func(r *FlowLogRecord, v string) (err error) { r.SrcAddr, err = parseIP(v); return },
+	"dstaddr":             func(r *FlowLogRecord, v string) (err error) { r.DstAddr, err = parseIP(v); return },
+	"srcport":             func(r *FlowLogRecord, v string) (err error) { r.SrcPort, err = strconv.Atoi(v); return },
+	"dstport":             func(r *FlowLogRecord, v string) (err error) { r.DstPort, err = strconv.Atoi(v); return },
+	"protocol":            func(r *FlowLogRecord, v string) (err error) { r.Protocol, err = strconv.Atoi(v); return },
+	"packets":             func(r *FlowLogRecord, v string) (err error) { r.Packets, err = strconv.ParseInt(v, 10, 64); return },
+	"bytes":               func(r *FlowLogRecord, v string) (err error) { r.Bytes, err = strconv.ParseInt(v, 10, 64); return },
+	"start":               func(r *FlowLogRecord, v string) (err error) { r.Start, err = parseUnix(v); return },
+	"end":                 func(r *FlowLogRecord, v string) (err error) { r.End, err = parseUnix(v); return },
+	"action":              func(r *FlowLogRecord, v string) error { r.Action = v; return nil },
+	"log-status":          func(r *FlowLogRecord, v string) error { r.LogStatus = v; return nil },
+	"vpc-id":              func(r *FlowLogRecord, v string) error { r.VPCID = v; return nil },
+	"subnet-id":           func(r *FlowLogRecord, v string) error { r.SubnetID = v; return nil },
+	"instance-id":         func(r *FlowLogRecord, v string) error { r.InstanceID = v; return nil },
+	"tcp-flags":           func(r *FlowLogRecord, v string) (err error) { r.TCPFlags, err = strconv.Atoi(v); return },
+	"type":                func(r *FlowLogRecord, v string) error { r.Type = v; return nil },
+	"pkt-srcaddr":         func(r *FlowLogRecord, v string) (err error) { r.PktSrcAddr, err = parseIP(v); return },
+	"pkt-dstaddr":         func(r *FlowLogRecord, v string) (err error) { r.PktDstAddr, err = parseIP(v); return },
+	"region":              func(r *FlowLogRecord, v string) error { r.Region = v; return nil },
+	"az-id":               func(r *FlowLogRecord, v string) error { r.AZID = v; return nil },
+	"sublocation-type":    func(r *FlowLogRecord, v string) error { r.SublocationType = v; return nil },
+	"sublocation-id":      func(r *FlowLogRecord, v string) error { r.SublocationID = v; return nil },
+	"pkt-src-aws-service": func(r *FlowLogRecord, v string) error { r.PktSrcAWSService = v; return nil },
+	"pkt-dst-aws-service": func(r *FlowLogRecord, v string) error { r.PktDstAWSService = v; return nil },
+	"flow-direction":      func(r *FlowLogRecord, v string) error { r.FlowDirection = v; return nil },
+	"traffic-path":        func(r *FlowLogRecord, v string) (err error) { r.TrafficPath, err = strconv.Atoi(v); return },
+}
+
+// ParseFlowLogFormat parses a custom flow log format, such as
+// "${version} ${vpc-id} ${srcaddr} ${dstaddr}".
+func ParseFlowLogFormat(s string) (FlowLogFormat, error) {
+	var f FlowLogFormat
+	for _, field := range strings.Fields(s) {
+		if !strings.HasPrefix(field, "${") || !strings.HasSuffix(field, "}") {
+			return nil, fmt.Errorf("cloudwatchlogsevt: malformed flow log field: %s", field)
+		}
+		name := field[2 : len(field)-1]
+		if _, ok := flowLogFields[name]; !ok {
+			return nil, fmt.Errorf("cloudwatchlogsevt: unsupported flow log field: %s", name)
+		}
+		f = append(f, name)
+	}
+	if len(f) == 0 {
+		return nil, errors.New("cloudwatchlogsevt: empty flow log format")
+	}
+	return f, nil
+}
+
+// Parse parses the message of a log event as a VPC flow log record in the
+// format. Values reported as "-", because they are not applicable or not
+// available, are left to their zero value.
+func (f FlowLogFormat) Parse(msg string) (*FlowLogRecord, error) {
+	values := strings.Fields(msg)
+	if len(values) != len(f) {
+		return nil, fmt.Errorf("cloudwatchlogsevt: flow log record has %d fields, want %d", len(values), len(f))
+	}
+
+	r := &FlowLogRecord{}
+	for i, name := range f {
+		if values[i] == "-" {
+			continue
+		}
+		set, ok := flowLogFields[name]
+		if !ok {
+			return nil, fmt.Errorf("cloudwatchlogsevt: unsupported flow log field: %s", name)
+		}
+		if err := set(r, values[i]); err != nil {
+			return nil, fmt.Errorf("cloudwatchlogsevt: invalid flow log field %s: %v", name, err)
+		}
+	}
+	return r, nil
+}
+
+// ParseFlowLog parses the message of a log event as a VPC flow log record in
+// the default format.
+func ParseFlowLog(msg string) (*FlowLogRecord, error) {
+	return DefaultFlowLogFormat.Parse(msg)
+}
+
+func parseIP(s string) (net.IP, error) {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address: %s", s)
+	}
+	return ip, nil
+}
+
+func parseUnix(s string) (time.Time, error) {
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(v, 0), nil
+}
@@ -0,0 +1,220 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudwatchlogsevt
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseFlowLog(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want *FlowLogRecord
+	}{
+		{
+			"2 123456789010 eni-1235b8ca123456789 172.31.16.139 172.31.16.21 20641 22 6 20 4249 1418530010 1418530070 ACCEPT OK",
+			&FlowLogRecord{
+				Version:     2,
+				AccountID:   "123456789010",
+				InterfaceID: "eni-1235b8ca123456789",
+				SrcAddr:     net.ParseIP("172.31.16.139"),
+				DstAddr:     net.ParseIP("172.31.16.21"),
+				SrcPort:     20641,
+				DstPort:     22,
+				Protocol:    6,
+				Packets:     20,
+				Bytes:       4249,
+				Start:       time.Unix(1418530010, 0),
+				End:         time.Unix(1418530070, 0),
+				Action:      FlowLogAccept,
+				LogStatus:   FlowLogOK,
+			},
+		},
+		{
+			"2 123456789010 eni-1235b8ca123456789 2001:db8:1234:a100:8d6e:3477:df66:f105 2001:db8:1234:a102:3304:8879:34cf:4071 34892 22 6 54 8855 1477913708 1477913820 REJECT OK",
+			&FlowLogRecord{
+				Version:     2,
+				AccountID:   "123456789010",
+				InterfaceID: "eni-1235b8ca123456789",
+				SrcAddr:     net.ParseIP("2001:db8:1234:a100:8d6e:3477:df66:f105"),
+				DstAddr:     net.ParseIP("2001:db8:1234:a102:3304:8879:34cf:4071"),
+				SrcPort:     34892,
+				DstPort:     22,
+				Protocol:    6,
+				Packets:     54,
+				Bytes:       8855,
+				Start:       time.Unix(1477913708, 0),
+				End:         time.Unix(1477913820, 0),
+				Action:      FlowLogReject,
+				LogStatus:   FlowLogOK,
+			},
+		},
+		{
+			"2 123456789010 eni-1235b8ca123456789 - - - - - - - 1431280876 1431280934 - NODATA",
+			&FlowLogRecord{
+				Version:     2,
+				AccountID:   "123456789010",
+				InterfaceID: "eni-1235b8ca123456789",
+				Start:       time.Unix(1431280876, 0),
+				End:         time.Unix(1431280934, 0),
+				LogStatus:   FlowLogNoData,
+			},
+		},
+		{
+			"2 123456789010 eni-11111111aaaaaaaaa - - - - - - - 1431280876 1431280934 - SKIPDATA",
+			&FlowLogRecord{
+				Version:     2,
+				AccountID:   "123456789010",
+				InterfaceID: "eni-11111111aaaaaaaaa",
+				Start:       time.Unix(1431280876, 0),
+				End:         time.Unix(1431280934, 0),
+				LogStatus:   FlowLogSkipData,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFlowLog(tt.msg)
+		if err != nil {
+			t.Errorf("%s: %v", tt.msg, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestFlowLogFormatParse(t *testing.T) {
+	tests := []struct {
+		format string
+		msg    string
+		want   *FlowLogRecord
+	}{
+		{
+			"${version} ${vpc-id} ${subnet-id} ${instance-id} ${interface-id} ${account-id} ${type} ${srcaddr} ${dstaddr} ${srcport} ${dstport} ${pkt-srcaddr} ${pkt-dstaddr} ${protocol} ${bytes} ${packets} ${start} ${end} ${action} ${tcp-flags} ${log-status}",
+			"3 vpc-12345678 subnet-012345678 i-07890123456 eni-23456789 123456789010 IPv4 52.213.180.42 10.0.0.62 43416 5001 52.213.180.42 10.0.0.62 6 568 8 1566848875 1566848933 ACCEPT 2 OK",
+			&FlowLogRecord{
+				Version:     3,
+				VPCID:       "vpc-12345678",
+				SubnetID:    "subnet-012345678",
+				InstanceID:  "i-07890123456",
+				InterfaceID: "eni-23456789",
+				AccountID:   "123456789010",
+				Type:        "IPv4",
+				SrcAddr:     net.ParseIP("52.213.180.42"),
+				DstAddr:     net.ParseIP("10.0.0.62"),
+				SrcPort:     43416,
+				DstPort:     5001,
+				PktSrcAddr:  net.ParseIP("52.213.180.42"),
+				PktDstAddr:  net.ParseIP("10.0.0.62"),
+				Protocol:    6,
+				Bytes:       568,
+				Packets:     8,
+				Start:       time.Unix(1566848875, 0),
+				End:         time.Unix(1566848933, 0),
+				Action:      FlowLogAccept,
+				TCPFlags:    2,
+				LogStatus:   FlowLogOK,
+			},
+		},
+		{
+			"${version} ${interface-id} ${srcaddr} ${dstaddr} ${pkt-srcaddr} ${pkt-dstaddr} ${region} ${az-id} ${sublocation-type} ${sublocation-id} ${log-status}",
+			"4 eni-1235b8ca123456789 10.0.1.5 10.0.0.220 10.0.1.5 203.0.113.5 us-east-1 use1-az4 outpost op-0123456789abcdef0 OK",
+			&FlowLogRecord{
+				Version:         4,
+				InterfaceID:     "eni-1235b8ca123456789",
+				SrcAddr:         net.ParseIP("10.0.1.5"),
+				DstAddr:         net.ParseIP("10.0.0.220"),
+				PktSrcAddr:      net.ParseIP("10.0.1.5"),
+				PktDstAddr:      net.ParseIP("203.0.113.5"),
+				Region:          "us-east-1",
+				AZID:            "use1-az4",
+				SublocationType: "outpost",
+				SublocationID:   "op-0123456789abcdef0",
+				LogStatus:       FlowLogOK,
+			},
+		},
+		{
+			"${version} ${srcaddr} ${dstaddr} ${srcport} ${dstport} ${pkt-src-aws-service} ${pkt-dst-aws-service} ${flow-direction} ${traffic-path} ${sublocation-type} ${sublocation-id}",
+			"5 10.0.0.71 52.95.128.179 34210 443 - S3 egress 8 - -",
+			&FlowLogRecord{
+				Version:          5,
+				SrcAddr:          net.ParseIP("10.0.0.71"),
+				DstAddr:          net.ParseIP("52.95.128.179"),
+				SrcPort:          34210,
+				DstPort:          443,
+				PktDstAWSService: "S3",
+				FlowDirection:    "egress",
+				TrafficPath:      8,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		f, err := ParseFlowLogFormat(tt.format)
+		if err != nil {
+			t.Errorf("%s: %v", tt.format, err)
+			continue
+		}
+		got, err := f.Parse(tt.msg)
+		if err != nil {
+			t.Errorf("%s: %v", tt.msg, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestParseFlowLogFormatInvalid(t *testing.T) {
+	tests := map[string]string{
+		"":                        "cloudwatchlogsevt: empty flow log format",
+		"${version} account-id":   "cloudwatchlogsevt: malformed flow log field: account-id",
+		"${version} ${vpc-id":     "cloudwatchlogsevt: malformed flow log field: ${vpc-id",
+		"${version} ${ecs-task}":  "cloudwatchlogsevt: unsupported flow log field: ecs-task",
+		"${version},${vpc-id}":    "cloudwatchlogsevt: unsupported flow log field: version},${vpc-id",
+		"${version} ${Version}\t": "cloudwatchlogsevt: unsupported flow log field: Version",
+	}
+
+	for format, want := range tests {
+		if _, err := ParseFlowLogFormat(format); err == nil || err.Error() != want {
+			t.Errorf("%q: got %v, want %s", format, err, want)
+		}
+	}
+}
+
+func TestParseFlowLogInvalid(t *testing.T) {
+	tests := map[string]string{
+		"2 123456789010 eni-1235b8ca123456789 172.31.16.139 172.31.16.21 20641 22 6 20 4249 1418530010 1418530070 ACCEPT":      "cloudwatchlogsevt: flow log record has 13 fields, want 14",
+		"2 123456789010 eni-1235b8ca123456789 172.31.16.139 172.31.16.21 20641 22 6 20 4249 1418530010 1418530070 ACCEPT OK 3": "cloudwatchlogsevt: flow log record has 15 fields, want 14",
+		"": "cloudwatchlogsevt: flow log record has 0 fields, want 14",
+		"2 123456789010 eni-1235b8ca123456789 172.31.16 172.31.16.21 20641 22 6 20 4249 1418530010 1418530070 ACCEPT OK":       "cloudwatchlogsevt: invalid flow log field srcaddr: invalid IP address: 172.31.16",
+		"2 123456789010 eni-1235b8ca123456789 172.31.16.139 172.31.16.21 ssh 22 6 20 4249 1418530010 1418530070 ACCEPT OK":     `cloudwatchlogsevt: invalid flow log field srcport: strconv.Atoi: parsing "ssh": invalid syntax`,
+		"2 123456789010 eni-1235b8ca123456789 172.31.16.139 172.31.16.21 20641 22 6 20 4249 1418530010.5 1418530070 ACCEPT OK": `cloudwatchlogsevt: invalid flow log field start: strconv.ParseInt: parsing "1418530010.5": invalid syntax`,
+	}
+
+	for msg, want := range tests {
+		if _, err := ParseFlowLog(msg); err == nil || err.Error() != want {
+			t.Errorf("%q: got %v, want %s", msg, err, want)
+		}
+	}
+}
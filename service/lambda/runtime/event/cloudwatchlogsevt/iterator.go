@@ -0,0 +1,238 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudwatchlogsevt
+
+import (
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// DefaultMaxSize is the default maximum size, in bytes, of the decompressed
+// data of an Amazon CloudWatch Logs event.
+const DefaultMaxSize = 64 << 20
+
+// ErrTooLarge is returned when the decompressed data of an event exceeds the
+// maximum size allowed by the Decoder.
+var ErrTooLarge = errors.New("cloudwatchlogsevt: decompressed data too large")
+
+// Iterator decodes the records of an Amazon CloudWatch Logs event one at a
+// time, straight from the compressed data, so that the whole batch of log
+// events never sits in memory at once.
+//
+// The contextual information of the records is read as it comes in the
+// payload. Amazon CloudWatch Logs writes it before the log events.
+type Iterator struct {
+	zr   *gzip.Reader
+	lr   *limitedReader
+	dec  *json.Decoder
+	ctx  payload
+	skip bool
+	done bool
+}
+
+// NewIterator returns an Iterator over the records of the raw JSON
+// Amazon CloudWatch Logs event. The Iterator must be closed once done with.
+func (d *Decoder) NewIterator(data []byte) (*Iterator, error) {
+	var raw struct {
+		Logs struct {
+			Data string `json:"data"`
+		} `json:"awslogs"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	zr, err := gzip.NewReader(base64.NewDecoder(base64.StdEncoding, strings.NewReader(raw.Logs.Data)))
+	if err != nil {
+		return nil, err
+	}
+
+	lr := &limitedReader{r: zr, n: d.maxSize()}
+	it := &Iterator{
+		zr:   zr,
+		lr:   lr,
+		dec:  json.NewDecoder(lr),
+		skip: d.SkipControl,
+	}
+	if err := it.expect(json.Delim('{')); err != nil {
+		zr.Close()
+		return nil, err
+	}
+	if err := it.readFields(); err != nil {
+		zr.Close()
+		return nil, err
+	}
+	return it, nil
+}
+
+// Next returns the next record of the event. It returns io.EOF when there are
+// no more records.
+func (it *Iterator) Next() (*EventRecord, error) {
+	if it.done || it.skip && it.ctx.MessageType == ControlMessage {
+		return nil, io.EOF
+	}
+
+	if !it.dec.More() {
+		if err := it.expect(json.Delim(']')); err != nil {
+			return nil, err
+		}
+		if err := it.readFields(); err != nil {
+			return nil, err
+		}
+		it.done = true
+		return nil, io.EOF
+	}
+
+	evt := &LogEvent{}
+	if err := it.dec.Decode(evt); err != nil {
+		return nil, it.err(err)
+	}
+	return &EventRecord{
+		Owner:               it.ctx.Owner,
+		LogGroup:            it.ctx.LogGroup,
+		LogStream:           it.ctx.LogStream,
+		LogEvent:            evt,
+		MessageType:         it.ctx.MessageType,
+		SubscriptionFilters: it.ctx.SubscriptionFilters,
+	}, nil
+}
+
+// Close releases the resources of the Iterator.
+func (it *Iterator) Close() error {
+	return it.zr.Close()
+}
+
+// readFields reads the fields of the payload up to the start of the log events
+// or to the end of the payload, in which case the Iterator is done.
+func (it *Iterator) readFields() error {
+	for it.dec.More() {
+		tok, err := it.dec.Token()
+		if err != nil {
+			return it.err(err)
+		}
+		key, _ := tok.(string)
+
+		var v interface{}
+		switch {
+		case strings.EqualFold(key, "logEvents"):
+			tok, err := it.dec.Token()
+			if err != nil {
+				return it.err(err)
+			}
+			if tok == json.Delim('[') {
+				return nil
+			}
+			if tok != nil {
+				return fmt.Errorf("cloudwatchlogsevt: unexpected log events: %v", tok)
+			}
+			continue
+		case strings.EqualFold(key, "messageType"):
+			v = &it.ctx.MessageType
+		case strings.EqualFold(key, "owner"):
+			v = &it.ctx.Owner
+		case strings.EqualFold(key, "logGroup"):
+			v = &it.ctx.LogGroup
+		case strings.EqualFold(key, "logStream"):
+			v = &it.ctx.LogStream
+		case strings.EqualFold(key, "subscriptionFilters"):
+			v = &it.ctx.SubscriptionFilters
+		default:
+			v = new(json.RawMessage)
+		}
+		if err := it.dec.Decode(v); err != nil {
+			return it.err(err)
+		}
+	}
+
+	it.done = true
+	if err := it.expect(json.Delim('}')); err != nil {
+		return err
+	}
+
+	// Read the compressed data to its end, so that its checksum is verified.
+	if _, err := io.Copy(ioutil.Discard, it.lr); err != nil {
+		return it.err(err)
+	}
+	return nil
+}
+
+// expect reads the next token and checks it is the given delimiter.
+func (it *Iterator) expect(delim json.Delim) error {
+	tok, err := it.dec.Token()
+	if err != nil {
+		return it.err(err)
+	}
+	if tok != delim {
+		return fmt.Errorf("cloudwatchlogsevt: unexpected token: %v, want %v", tok, delim)
+	}
+	return nil
+}
+
+// err reports the truncation of the data as io.ErrUnexpectedEOF rather than
+// io.EOF, which is reserved to the end of the records, or rather than the
+// syntax error of a JSON value cut short.
+func (it *Iterator) err(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	if se, ok := err.(*json.SyntaxError); ok && it.lr.eof && se.Offset >= it.lr.read {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+func (d *Decoder) maxSize() int64 {
+	if d.MaxSize == 0 {
+		return DefaultMaxSize
+	}
+	return d.MaxSize
+}
+
+// limitedReader reads from r until n bytes have been read, then fails with
+// ErrTooLarge if r has more data. It keeps track of the number of bytes read
+// and of whether r reached its end.
+type limitedReader struct {
+	r    io.Reader
+	n    int64
+	read int64
+	eof  bool
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		var b [1]byte
+		if n, err := l.r.Read(b[:]); n == 0 {
+			l.eof = err == io.EOF
+			return 0, err
+		}
+		return 0, ErrTooLarge
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	l.read += int64(n)
+	l.eof = err == io.EOF
+	return n, err
+}
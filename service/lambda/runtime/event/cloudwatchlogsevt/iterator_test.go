@@ -0,0 +1,220 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudwatchlogsevt
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// compress returns the Amazon CloudWatch Logs event holding the gzipped
+// payload, truncated to n bytes if n is positive.
+func compress(t *testing.T, payload string, n int) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(payload)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if n > 0 {
+		data = data[:n]
+	}
+
+	evt, err := json.Marshal(map[string]interface{}{"awslogs": map[string][]byte{"data": data}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return evt
+}
+
+// iterate returns the records of the event along with the error the Iterator
+// stopped at.
+func iterate(d *Decoder, data []byte) (EventRecords, error) {
+	it, err := d.NewIterator(data)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var recs EventRecords
+	for {
+		rec, err := it.Next()
+		if err != nil {
+			return recs, err
+		}
+		recs = append(recs, rec)
+	}
+}
+
+func TestIterator(t *testing.T) {
+	for _, file := range []string{"testdata/event-subscription.json", "testdata/event-control.json"} {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want Event
+		if err := json.Unmarshal(data, &want); err != nil {
+			t.Fatal(err)
+		}
+
+		recs, err := iterate(&Decoder{}, data)
+		if err != io.EOF {
+			t.Errorf("%s: got %v, want %v", file, err, io.EOF)
+		}
+		if !reflect.DeepEqual(recs, want.Records) {
+			t.Errorf("%s: got %v, want %v", file, recs, want.Records)
+		}
+	}
+}
+
+func TestIteratorNextAfterEOF(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/event-subscription.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	it, err := (&Decoder{}).NewIterator(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer it.Close()
+
+	for i := 0; i < 2; i++ {
+		if _, err := it.Next(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if rec, err := it.Next(); rec != nil || err != io.EOF {
+			t.Errorf("got %v, %v, want %v", rec, err, io.EOF)
+		}
+	}
+}
+
+func TestIteratorFieldsAfterLogEvents(t *testing.T) {
+	payload := `{"messageType":"DATA_MESSAGE","logEvents":[` +
+		`{"id":"1","timestamp":1440442987000,"message":"first"},` +
+		`{"id":"2","timestamp":1440442987001,"message":"second"}` +
+		`],"owner":"123456789123","logGroup":"testLogGroup","logStream":"testLogStream","subscriptionFilters":["testFilter"],"unknown":{"a":[1]}}`
+
+	recs, err := iterate(&Decoder{}, compress(t, payload, 0))
+	if err != io.EOF {
+		t.Errorf("got %v, want %v", err, io.EOF)
+	}
+
+	// The records only carry the contextual information read before them.
+	want := EventRecords{
+		{MessageType: DataMessage, LogEvent: &LogEvent{ID: "1", Timestamp: time.Unix(1440442987, 0), Message: "first"}},
+		{MessageType: DataMessage, LogEvent: &LogEvent{ID: "2", Timestamp: time.Unix(1440442987, 1e6), Message: "second"}},
+	}
+	if !reflect.DeepEqual(recs, want) {
+		t.Errorf("got %v, want %v", recs, want)
+	}
+}
+
+func TestIteratorSkipControl(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/event-control.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recs, err := iterate(&Decoder{SkipControl: true}, data)
+	if err != io.EOF || len(recs) != 0 {
+		t.Errorf("got %v, %v, want no records", recs, err)
+	}
+
+	data, err = ioutil.ReadFile("testdata/event-subscription.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	recs, err = iterate(&Decoder{SkipControl: true}, data)
+	if err != io.EOF || len(recs) != 2 {
+		t.Errorf("got %v, %v, want 2 records", recs, err)
+	}
+}
+
+func TestIteratorTooLarge(t *testing.T) {
+	payload := `{"messageType":"DATA_MESSAGE","owner":"123456789123","logGroup":"testLogGroup","logStream":"testLogStream","subscriptionFilters":["testFilter"],"logEvents":[` +
+		`{"id":"1","timestamp":1440442987000,"message":"first"},` +
+		`{"id":"2","timestamp":1440442987001,"message":"second"}]}`
+	data := compress(t, payload, 0)
+
+	for _, n := range []int64{16, 180, int64(len(payload)) - 1} {
+		d := &Decoder{MaxSize: n}
+		if _, err := iterate(d, data); err != ErrTooLarge {
+			t.Errorf("%d: got %v from the iterator, want %v", n, err, ErrTooLarge)
+		}
+		if _, err := d.Decode(data); err != ErrTooLarge {
+			t.Errorf("%d: got %v from the decoder, want %v", n, err, ErrTooLarge)
+		}
+	}
+
+	d := &Decoder{MaxSize: int64(len(payload))}
+	if recs, err := iterate(d, data); err != io.EOF || len(recs) != 2 {
+		t.Errorf("got %v, %v, want 2 records", recs, err)
+	}
+	if _, err := d.Decode(data); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestIteratorTruncated(t *testing.T) {
+	payload := `{"messageType":"DATA_MESSAGE","owner":"123456789123","logGroup":"testLogGroup","logStream":"testLogStream","subscriptionFilters":["testFilter"],"logEvents":[` +
+		`{"id":"1","timestamp":1440442987000,"message":"first"},` +
+		`{"id":"2","timestamp":1440442987001,"message":"second"}]}`
+
+	// The gzip stream is cut in its header, its data or its trailer.
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	w.Write([]byte(payload))
+	w.Close()
+	for _, n := range []int{20, gz.Len() / 2, gz.Len() - 4, gz.Len() - 1} {
+		recs, err := iterate(&Decoder{}, compress(t, payload, n))
+		if err != io.ErrUnexpectedEOF {
+			t.Errorf("gzip cut at %d: got %v after %d records, want %v", n, err, len(recs), io.ErrUnexpectedEOF)
+		}
+	}
+
+	// The gzip stream is complete but the payload is cut.
+	for _, n := range []int{1, 60, len(payload) - 60, len(payload) - 2, len(payload) - 1} {
+		recs, err := iterate(&Decoder{}, compress(t, payload[:n], 0))
+		if err != io.ErrUnexpectedEOF {
+			t.Errorf("payload cut at %d: got %v after %d records, want %v", n, err, len(recs), io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+func TestIteratorInvalid(t *testing.T) {
+	for _, payload := range []string{
+		`[]`,
+		`{"logEvents":{}}`,
+		`{"logEvents":[{"id":1}]}`,
+		`{"owner":x}`,
+	} {
+		if _, err := iterate(&Decoder{}, compress(t, payload, 0)); err == io.EOF || err == io.ErrUnexpectedEOF {
+			t.Errorf("%s: got %v", payload, err)
+		}
+	}
+}
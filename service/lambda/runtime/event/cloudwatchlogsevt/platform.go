@@ -0,0 +1,250 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudwatchlogsevt
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Start represents the START line AWS Lambda logs when an invocation begins.
+type Start struct {
+	// The ID of the invocation request.
+	RequestID string
+
+	// The version of the function being invoked.
+	Version string
+}
+
+// End represents the END line AWS Lambda logs when an invocation ends.
+type End struct {
+	// The ID of the invocation request.
+	RequestID string
+}
+
+// Report represents the REPORT line AWS Lambda logs after an invocation, with
+// the duration and resources it used.
+type Report struct {
+	// The ID of the invocation request.
+	RequestID string
+
+	// The time the handler spent processing the request.
+	Duration time.Duration
+
+	// The time the invocation has been billed for.
+	BilledDuration time.Duration
+
+	// The memory allocated to the function, in MB.
+	MemorySize int
+
+	// The maximum memory used by the function, in MB.
+	MaxMemoryUsed int
+
+	// The time spent initializing the function. Provided for the first
+	// invocation of an execution environment, that is on cold start,
+	// otherwise 0.
+	InitDuration time.Duration
+
+	// The AWS X-Ray trace ID of the invocation, if tracing is enabled.
+	XRayTraceID string
+}
+
+// ColdStart reports whether the invocation initialized a new execution
+// environment.
+func (r *Report) ColdStart() bool {
+	return r.InitDuration > 0
+}
+
+// Timeout represents the line AWS Lambda logs when an invocation times out.
+type Timeout struct {
+	// The ID of the invocation request, if logged.
+	RequestID string
+
+	// The time the timeout occurred, if logged.
+	Timestamp time.Time
+
+	// The timeout of the function.
+	After time.Duration
+}
+
+// Error represents an error line logged by the AWS Lambda runtime.
+type Error struct {
+	// The ID of the invocation request, if logged.
+	RequestID string
+
+	// The time the error occurred, if logged.
+	Timestamp time.Time
+
+	// The error message.
+	Message string
+}
+
+// timestampPattern matches the RFC 3339 UTC timestamps of the runtimes, such as
+// "2017-03-17T08:00:00.123Z".
+const timestampPattern = `\d{4}-\d{2}-\d{2}T\S+Z`
+
+var (
+	startLine   = regexp.MustCompile(`^START RequestId: (\S+)(?: Version: (\S+))?`)
+	endLine     = regexp.MustCompile(`^END RequestId: (\S+)`)
+	reportLine  = regexp.MustCompile(`^REPORT RequestId: (\S+)`)
+	timeoutLine = regexp.MustCompile(`^(?:(` + timestampPattern + `)\s+)?(?:(\S+)\s+)?Task timed out after ([0-9.]+) seconds`)
+
+	// The error lines of the Python and Node.js runtimes, and of AWS Lambda
+	// when a runtime such as Go exits, respectively.
+	errorLine        = regexp.MustCompile(`^\[ERROR\]\s+(?:(` + timestampPattern + `)\s+(\S+)\s+)?(.*)`)
+	errorPrefixLine  = regexp.MustCompile(`^(` + timestampPattern + `)\t(\S+)\tERROR\t(.*)`)
+	runtimeErrorLine = regexp.MustCompile(`^()RequestId: (\S+) Error: (.*)`)
+)
+
+// ParseStart parses a START line. It returns false if msg is not one.
+func ParseStart(msg string) (*Start, bool) {
+	m := startLine.FindStringSubmatch(msg)
+	if m == nil {
+		return nil, false
+	}
+	return &Start{RequestID: m[1], Version: m[2]}, true
+}
+
+// ParseEnd parses an END line. It returns false if msg is not one.
+func ParseEnd(msg string) (*End, bool) {
+	m := endLine.FindStringSubmatch(msg)
+	if m == nil {
+		return nil, false
+	}
+	return &End{RequestID: m[1]}, true
+}
+
+// ParseReport parses a REPORT line. It returns false if msg is not one, or if
+// one of its metrics is malformed.
+func ParseReport(msg string) (*Report, bool) {
+	m := reportLine.FindStringSubmatch(msg)
+	if m == nil {
+		return nil, false
+	}
+
+	r := &Report{RequestID: m[1]}
+	// The metrics are separated by tabs, the X-Ray ones being on a line of
+	// their own.
+	fields := strings.FieldsFunc(msg, func(r rune) bool { return r == '\t' || r == '\n' })
+	for _, field := range fields[1:] {
+		field = strings.TrimSpace(field)
+		i := strings.Index(field, ": ")
+		if i < 0 {
+			continue
+		}
+		key, value := field[:i], field[i+2:]
+
+		var err error
+		switch key {
+		case "Duration":
+			r.Duration, err = parseMillis(value)
+		case "Billed Duration":
+			r.BilledDuration, err = parseMillis(value)
+		case "Init Duration":
+			r.InitDuration, err = parseMillis(value)
+		case "Memory Size":
+			r.MemorySize, err = parseMegabytes(value)
+		case "Max Memory Used":
+			r.MaxMemoryUsed, err = parseMegabytes(value)
+		case "XRAY TraceId":
+			r.XRayTraceID = strings.TrimSpace(value)
+		}
+		if err != nil {
+			return nil, false
+		}
+	}
+	return r, true
+}
+
+// ParseTimeout parses a timeout line. It returns false if msg is not one.
+func ParseTimeout(msg string) (*Timeout, bool) {
+	m := timeoutLine.FindStringSubmatch(msg)
+	if m == nil {
+		return nil, false
+	}
+
+	after, err := time.ParseDuration(m[3] + "s")
+	if err != nil {
+		return nil, false
+	}
+	t := &Timeout{RequestID: m[2], After: after}
+	if m[1] != "" {
+		if t.Timestamp, err = time.Parse(time.RFC3339Nano, m[1]); err != nil {
+			return nil, false
+		}
+	}
+	return t, true
+}
+
+// ParseError parses an error line of the Python ("[ERROR] ...") or Node.js
+// ("<timestamp>\t<request id>\tERROR\t...") runtimes, or the line AWS Lambda
+// logs when the runtime exits ("RequestId: <request id> Error: ..."), as the
+// Go one does on panic. It returns false if msg is not one.
+func ParseError(msg string) (*Error, bool) {
+	var m []string
+	for _, re := range []*regexp.Regexp{errorLine, errorPrefixLine, runtimeErrorLine} {
+		if m = re.FindStringSubmatch(msg); m != nil {
+			break
+		}
+	}
+	if m == nil {
+		return nil, false
+	}
+
+	e := &Error{RequestID: m[2], Message: strings.TrimSpace(m[3])}
+	if m[1] != "" {
+		var err error
+		if e.Timestamp, err = time.Parse(time.RFC3339Nano, m[1]); err != nil {
+			return nil, false
+		}
+	}
+	return e, true
+}
+
+// ParsePlatformMessage parses the message of a log event logged by AWS Lambda.
+// It returns a *Start, *End, *Report, *Timeout or *Error, or nil if the
+// message is none of them.
+func ParsePlatformMessage(msg string) interface{} {
+	if v, ok := ParseStart(msg); ok {
+		return v
+	}
+	if v, ok := ParseEnd(msg); ok {
+		return v
+	}
+	if v, ok := ParseReport(msg); ok {
+		return v
+	}
+	if v, ok := ParseTimeout(msg); ok {
+		return v
+	}
+	if v, ok := ParseError(msg); ok {
+		return v
+	}
+	return nil
+}
+
+// parseMillis parses a duration such as "12.34 ms".
+func parseMillis(s string) (time.Duration, error) {
+	return time.ParseDuration(strings.TrimSuffix(strings.TrimSpace(s), " ms") + "ms")
+}
+
+// parseMegabytes parses a memory size such as "128 MB".
+func parseMegabytes(s string) (int, error) {
+	return strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(s), " MB"))
+}
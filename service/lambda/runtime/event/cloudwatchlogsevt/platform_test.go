@@ -0,0 +1,128 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudwatchlogsevt
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParsePlatformMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want interface{}
+	}{
+		{
+			name: "start",
+			msg:  "START RequestId: 6bc28136-xmpl-4365-b021-0ce6b2e64ab0 Version: $LATEST\n",
+			want: &Start{RequestID: "6bc28136-xmpl-4365-b021-0ce6b2e64ab0", Version: "$LATEST"},
+		},
+		{
+			name: "end",
+			msg:  "END RequestId: 6bc28136-xmpl-4365-b021-0ce6b2e64ab0\n",
+			want: &End{RequestID: "6bc28136-xmpl-4365-b021-0ce6b2e64ab0"},
+		},
+		{
+			name: "report",
+			msg:  "REPORT RequestId: 6bc28136-xmpl-4365-b021-0ce6b2e64ab0\tDuration: 24.76 ms\tBilled Duration: 25 ms\tMemory Size: 128 MB\tMax Memory Used: 83 MB\t\n",
+			want: &Report{RequestID: "6bc28136-xmpl-4365-b021-0ce6b2e64ab0", Duration: 24760 * time.Microsecond, BilledDuration: 25 * time.Millisecond, MemorySize: 128, MaxMemoryUsed: 83},
+		},
+		{
+			name: "report on cold start with tracing",
+			msg:  "REPORT RequestId: 6bc28136-xmpl-4365-b021-0ce6b2e64ab0\tDuration: 1024.00 ms\tBilled Duration: 1100 ms\tMemory Size: 512 MB\tMax Memory Used: 64 MB\tInit Duration: 138.12 ms\t\nXRAY TraceId: 1-5e34a614-10bdxmplf1fb44f07bc535a1\tSegmentId: 07f5xmpl2d1f6f85\tSampled: true\t\n",
+			want: &Report{RequestID: "6bc28136-xmpl-4365-b021-0ce6b2e64ab0", Duration: 1024 * time.Millisecond, BilledDuration: 1100 * time.Millisecond, MemorySize: 512, MaxMemoryUsed: 64, InitDuration: 138120 * time.Microsecond, XRayTraceID: "1-5e34a614-10bdxmplf1fb44f07bc535a1"},
+		},
+		{
+			name: "timeout",
+			msg:  "2019-10-10T14:54:13.417Z 6bc28136-xmpl-4365-b021-0ce6b2e64ab0 Task timed out after 3.00 seconds\n",
+			want: &Timeout{RequestID: "6bc28136-xmpl-4365-b021-0ce6b2e64ab0", Timestamp: time.Date(2019, 10, 10, 14, 54, 13, 417e6, time.UTC), After: 3 * time.Second},
+		},
+		{
+			name: "timeout without prefix",
+			msg:  "Task timed out after 900.10 seconds",
+			want: &Timeout{After: 900100 * time.Millisecond},
+		},
+		{
+			name: "python unhandled exception",
+			msg:  "[ERROR] KeyError: 'name'\nTraceback (most recent call last):\n  File \"/var/task/lambda_function.py\", line 4, in lambda_handler\n    return event['name']\n",
+			want: &Error{Message: "KeyError: 'name'"},
+		},
+		{
+			name: "python logger",
+			msg:  "[ERROR]\t2019-10-10T14:54:13.417Z\t6bc28136-xmpl-4365-b021-0ce6b2e64ab0\tCould not connect to the database\n",
+			want: &Error{RequestID: "6bc28136-xmpl-4365-b021-0ce6b2e64ab0", Timestamp: time.Date(2019, 10, 10, 14, 54, 13, 417e6, time.UTC), Message: "Could not connect to the database"},
+		},
+		{
+			name: "python error starting with a word ending in Z",
+			msg:  "[ERROR] FooZ bar baz",
+			want: &Error{Message: "FooZ bar baz"},
+		},
+		{
+			name: "node.js unhandled error",
+			msg:  "2019-10-10T14:54:13.417Z\t6bc28136-xmpl-4365-b021-0ce6b2e64ab0\tERROR\tInvoke Error \t{\"errorType\":\"TypeError\",\"errorMessage\":\"Cannot read property 'x' of undefined\"}\n",
+			want: &Error{RequestID: "6bc28136-xmpl-4365-b021-0ce6b2e64ab0", Timestamp: time.Date(2019, 10, 10, 14, 54, 13, 417e6, time.UTC), Message: "Invoke Error \t{\"errorType\":\"TypeError\",\"errorMessage\":\"Cannot read property 'x' of undefined\"}"},
+		},
+		{
+			name: "go panic",
+			msg:  "RequestId: 6bc28136-xmpl-4365-b021-0ce6b2e64ab0 Error: Runtime exited with error: exit status 2\nRuntime.ExitError\n",
+			want: &Error{RequestID: "6bc28136-xmpl-4365-b021-0ce6b2e64ab0", Message: "Runtime exited with error: exit status 2"},
+		},
+		{
+			name: "go log",
+			msg:  "2019/10/10 14:54:13 could not connect to the database\n",
+		},
+		{
+			name: "node.js info",
+			msg:  "2019-10-10T14:54:13.417Z\t6bc28136-xmpl-4365-b021-0ce6b2e64ab0\tINFO\tHello\n",
+		},
+		{
+			name: "python info",
+			msg:  "[INFO]\t2019-10-10T14:54:13.417Z\t6bc28136-xmpl-4365-b021-0ce6b2e64ab0\tHello\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParsePlatformMessage(tt.msg)
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("got %#v, want nil", got)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrorTimestamp(t *testing.T) {
+	// A first word ending in Z is part of the message, not a timestamp.
+	for _, msg := range []string{"[ERROR] FooZ bar baz", "[ERROR] 2019-10-10Z bar baz"} {
+		e, ok := ParseError(msg)
+		if !ok || !e.Timestamp.IsZero() || e.RequestID != "" || e.Message != msg[len("[ERROR] "):] {
+			t.Errorf("%q: got %#v, %v", msg, e, ok)
+		}
+	}
+
+	if e, ok := ParseError("FooZ\tbar\tERROR\tbaz"); ok {
+		t.Errorf("unexpected error line: %#v", e)
+	}
+}
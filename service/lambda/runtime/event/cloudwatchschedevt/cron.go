@@ -0,0 +1,327 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudwatchschedevt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The years a cron expression can span.
+const (
+	minYear = 1970
+	maxYear = 2199
+)
+
+// Cron represents a cron(minutes hours day-of-month month day-of-week year)
+// schedule expression. Either the day-of-month or the day-of-week field is
+// "?", and the other one specifies the days the schedule ticks.
+type Cron struct {
+	expr    string
+	minutes []bool
+	hours   []bool
+	months  []bool
+	years   []bool
+
+	// Exactly one of dom and dow is nil, when its field is "?".
+	dom []dayOfMonth
+	dow []dayOfWeek
+}
+
+// dayOfMonth matches a day of the month, given as a number, as the last day
+// ("L") or as the weekday nearest to a number ("15W") or to the last day
+// ("LW").
+type dayOfMonth struct {
+	day     int // 0 for the last day of the month
+	weekday bool
+	set     []bool
+}
+
+// dayOfWeek matches a day of the week, given as a number, as the last of the
+// month ("5L") or as the nth of the month ("5#3").
+type dayOfWeek struct {
+	last bool
+	nth  int
+	set  []bool
+}
+
+var monthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var dayNames = map[string]int{
+	"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7,
+}
+
+// ParseCron parses the six fields of a cron expression, such as
+// "0 18 ? * MON-FRI *".
+func ParseCron(s string) (*Cron, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("cloudwatchschedevt: cron expression must have 6 fields: %s", s)
+	}
+
+	c := &Cron{expr: strings.Join(fields, " ")}
+	var err error
+	if c.minutes, err = parseSet(fields[0], 0, 59, nil); err != nil {
+		return nil, err
+	}
+	if c.hours, err = parseSet(fields[1], 0, 23, nil); err != nil {
+		return nil, err
+	}
+	if c.months, err = parseSet(fields[3], 1, 12, monthNames); err != nil {
+		return nil, err
+	}
+	if c.years, err = parseSet(fields[5], minYear, maxYear, nil); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case fields[2] == "?" && fields[4] == "?", fields[2] != "?" && fields[4] != "?":
+		return nil, fmt.Errorf("cloudwatchschedevt: exactly one of day-of-month and day-of-week must be ?: %s", s)
+	case fields[2] != "?":
+		if c.dom, err = parseDaysOfMonth(fields[2]); err != nil {
+			return nil, err
+		}
+	default:
+		if c.dow, err = parseDaysOfWeek(fields[4]); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func parseDaysOfMonth(field string) ([]dayOfMonth, error) {
+	var days []dayOfMonth
+	for _, item := range strings.Split(field, ",") {
+		var d dayOfMonth
+		switch {
+		case item == "L":
+		case item == "LW":
+			d.weekday = true
+		case strings.HasSuffix(item, "W"):
+			n, err := parseValue(item[:len(item)-1], 1, 31, nil)
+			if err != nil {
+				return nil, err
+			}
+			d.day, d.weekday = n, true
+		default:
+			set, err := parseSet(item, 1, 31, nil)
+			if err != nil {
+				return nil, err
+			}
+			d.set = set
+		}
+		days = append(days, d)
+	}
+	return days, nil
+}
+
+func parseDaysOfWeek(field string) ([]dayOfWeek, error) {
+	var days []dayOfWeek
+	for _, item := range strings.Split(field, ",") {
+		var d dayOfWeek
+		var err error
+		switch {
+		case item == "L":
+			d.set, err = parseSet("SAT", 1, 7, dayNames)
+		case strings.HasSuffix(item, "L"):
+			d.last = true
+			d.set, err = parseSet(item[:len(item)-1], 1, 7, dayNames)
+		case strings.Contains(item, "#"):
+			i := strings.IndexByte(item, '#')
+			if d.nth, err = parseValue(item[i+1:], 1, 5, nil); err != nil {
+				return nil, err
+			}
+			d.set, err = parseSet(item[:i], 1, 7, dayNames)
+		default:
+			d.set, err = parseSet(item, 1, 7, dayNames)
+		}
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, d)
+	}
+	return days, nil
+}
+
+// parseSet parses a comma separated list of values, ranges ("a-b"), steps
+// ("*/n", "a/n", "a-b/n") or wildcards ("*") within [min, max].
+func parseSet(field string, min, max int, names map[string]int) ([]bool, error) {
+	set := make([]bool, max+1)
+	for _, item := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			var err error
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step <= 0 {
+				return nil, fmt.Errorf("cloudwatchschedevt: invalid cron step: %s", item)
+			}
+			item = item[:i]
+		}
+
+		lo, hi := min, max
+		switch i := strings.IndexByte(item, '-'); {
+		case item == "*":
+		case i >= 0:
+			var err error
+			if lo, err = parseValue(item[:i], min, max, names); err != nil {
+				return nil, err
+			}
+			if hi, err = parseValue(item[i+1:], min, max, names); err != nil {
+				return nil, err
+			}
+			if lo > hi {
+				return nil, fmt.Errorf("cloudwatchschedevt: invalid cron range: %s", item)
+			}
+		default:
+			v, err := parseValue(item, min, max, names)
+			if err != nil {
+				return nil, err
+			}
+			lo = v
+			if step == 1 {
+				hi = v
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+func parseValue(s string, min, max int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < min || v > max {
+		return 0, fmt.Errorf("cloudwatchschedevt: invalid cron value: %s", s)
+	}
+	return v, nil
+}
+
+// Next returns the first tick of the schedule strictly after the given time,
+// or the zero time if there is none before the year 2200.
+func (c *Cron) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	if t.Year() < minYear {
+		t = time.Date(minYear, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	for t.Year() <= maxYear {
+		switch {
+		case !c.years[t.Year()]:
+			t = time.Date(t.Year()+1, 1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.months[t.Month()]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !c.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !c.hours[t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+		case !c.minutes[t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Matches reports whether the time is a tick of the schedule.
+func (c *Cron) Matches(t time.Time) bool {
+	t = t.UTC().Truncate(time.Minute)
+	return t.Year() >= minYear && t.Year() <= maxYear &&
+		c.years[t.Year()] &&
+		c.months[t.Month()] &&
+		c.matchDay(t) &&
+		c.hours[t.Hour()] &&
+		c.minutes[t.Minute()]
+}
+
+// String returns the schedule expression.
+func (c *Cron) String() string {
+	return "cron(" + c.expr + ")"
+}
+
+func (c *Cron) matchDay(t time.Time) bool {
+	for _, d := range c.dom {
+		if d.match(t) {
+			return true
+		}
+	}
+	for _, d := range c.dow {
+		if d.match(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d dayOfMonth) match(t time.Time) bool {
+	if d.set != nil {
+		return d.set[t.Day()]
+	}
+
+	last := daysIn(t.Year(), t.Month())
+	day := d.day
+	if day == 0 || day > last {
+		day = last
+	}
+	if !d.weekday {
+		return t.Day() == day
+	}
+
+	// Move to the nearest weekday within the month.
+	switch time.Date(t.Year(), t.Month(), day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			day += 2
+		} else {
+			day--
+		}
+	case time.Sunday:
+		if day == last {
+			day -= 2
+		} else {
+			day++
+		}
+	}
+	return t.Day() == day
+}
+
+func (d dayOfWeek) match(t time.Time) bool {
+	if !d.set[int(t.Weekday())+1] {
+		return false
+	}
+	switch {
+	case d.last:
+		return t.Day()+7 > daysIn(t.Year(), t.Month())
+	case d.nth > 0:
+		return (t.Day()-1)/7+1 == d.nth
+	}
+	return true
+}
+
+// daysIn returns the number of days of the month.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,118 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudwatchschedevt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	tests := []struct {
+		expr  string
+		after time.Time
+		want  []time.Time
+	}{
+		// Minutes, hours and steps.
+		{"0/15 * * * ? *", date("2017-03-17T12:07:30Z"), []time.Time{date("2017-03-17T12:15:00Z"), date("2017-03-17T12:30:00Z")}},
+		{"0/5 8-17 ? * MON-FRI *", date("2017-03-17T17:57:00Z"), []time.Time{date("2017-03-20T08:00:00Z")}},
+
+		// Month and year boundaries.
+		{"0 0 1 * ? *", date("2017-01-31T23:59:30Z"), []time.Time{date("2017-02-01T00:00:00Z"), date("2017-03-01T00:00:00Z")}},
+		{"*/15 * * * ? *", date("2017-12-31T23:59:00Z"), []time.Time{date("2018-01-01T00:00:00Z")}},
+		{"0 0 1 JAN ? *", date("2017-06-15T00:00:00Z"), []time.Time{date("2018-01-01T00:00:00Z"), date("2019-01-01T00:00:00Z")}},
+		{"0 12 31 * ? *", date("2017-04-01T00:00:00Z"), []time.Time{date("2017-05-31T12:00:00Z"), date("2017-07-31T12:00:00Z")}},
+		{"0 12 29 FEB ? *", date("2017-01-01T00:00:00Z"), []time.Time{date("2020-02-29T12:00:00Z")}},
+
+		// Last day of the month.
+		{"0 12 L * ? *", date("2017-02-10T00:00:00Z"), []time.Time{date("2017-02-28T12:00:00Z"), date("2017-03-31T12:00:00Z")}},
+		{"0 12 L * ? *", date("2016-02-10T00:00:00Z"), []time.Time{date("2016-02-29T12:00:00Z")}},
+
+		// Nearest weekday.
+		{"0 12 15W * ? *", date("2017-04-01T00:00:00Z"), []time.Time{date("2017-04-14T12:00:00Z")}},
+		{"0 12 15W * ? *", date("2017-10-01T00:00:00Z"), []time.Time{date("2017-10-16T12:00:00Z")}},
+		{"0 12 1W * ? *", date("2017-03-31T00:00:00Z"), []time.Time{date("2017-04-03T12:00:00Z")}},
+		{"0 12 30W * ? *", date("2017-04-01T00:00:00Z"), []time.Time{date("2017-04-28T12:00:00Z")}},
+		{"0 12 31W * ? *", date("2017-04-01T00:00:00Z"), []time.Time{date("2017-04-28T12:00:00Z")}},
+
+		// Last weekday of the month.
+		{"0 12 LW * ? *", date("2017-09-01T00:00:00Z"), []time.Time{date("2017-09-29T12:00:00Z"), date("2017-10-31T12:00:00Z")}},
+		{"0 12 LW * ? *", date("2017-12-01T00:00:00Z"), []time.Time{date("2017-12-29T12:00:00Z")}},
+
+		// Nth and last day of the week of the month.
+		{"0 10 ? * 6#3 *", date("2017-03-01T00:00:00Z"), []time.Time{date("2017-03-17T10:00:00Z"), date("2017-04-21T10:00:00Z")}},
+		{"0 10 ? * MON#1 *", date("2017-03-07T00:00:00Z"), []time.Time{date("2017-04-03T10:00:00Z")}},
+		{"0 10 ? * 6L *", date("2017-03-01T00:00:00Z"), []time.Time{date("2017-03-31T10:00:00Z"), date("2017-04-28T10:00:00Z")}},
+		{"0 10 ? * L *", date("2017-03-01T00:00:00Z"), []time.Time{date("2017-03-04T10:00:00Z"), date("2017-03-11T10:00:00Z")}},
+
+		// Year bounds.
+		{"0 0 1 1 ? 2020", date("2017-06-15T00:00:00Z"), []time.Time{date("2020-01-01T00:00:00Z"), {}}},
+		{"0 0 1 1 ? 2018-2019", date("2019-01-01T00:00:00Z"), []time.Time{{}}},
+		{"59 23 31 12 ? *", date("2199-12-31T23:58:00Z"), []time.Time{date("2199-12-31T23:59:00Z"), {}}},
+		{"0 0 1 1 ? *", date("1960-06-01T00:00:00Z"), []time.Time{date("1970-01-01T00:00:00Z")}},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.expr, err)
+		}
+		after := tt.after
+		for _, want := range tt.want {
+			got := c.Next(after)
+			if !got.Equal(want) {
+				t.Errorf("cron(%s): Next(%v) = %v, want %v", tt.expr, after, got, want)
+				break
+			}
+			if !want.IsZero() && !c.Matches(want) {
+				t.Errorf("cron(%s): Matches(%v) = false", tt.expr, want)
+			}
+			after = got
+		}
+	}
+}
+
+func TestCronMatches(t *testing.T) {
+	tests := []struct {
+		expr  string
+		t     time.Time
+		match bool
+	}{
+		{"0 10 * * ? *", date("2017-03-17T10:00:00Z"), true},
+		{"0 10 * * ? *", date("2017-03-17T10:00:42Z"), true},
+		{"0 10 * * ? *", date("2017-03-17T12:00:00+02:00"), true},
+		{"0 10 * * ? *", date("2017-03-17T10:01:00Z"), false},
+		{"0 18 ? * MON-FRI *", date("2017-03-18T18:00:00Z"), false},
+		{"0 12 15W * ? *", date("2017-04-15T12:00:00Z"), false},
+		{"0 12 LW * ? *", date("2017-12-31T12:00:00Z"), false},
+		{"0 10 ? * 6#3 *", date("2017-03-24T10:00:00Z"), false},
+		{"0 10 ? * 6L *", date("2017-03-24T10:00:00Z"), false},
+		{"0 0 1 1 ? 2018-2019", date("2020-01-01T00:00:00Z"), false},
+		{"0 0 1 1 ? *", date("1969-01-01T00:00:00Z"), false},
+		{"0 0 1 1 ? *", date("2200-01-01T00:00:00Z"), false},
+	}
+
+	for _, tt := range tests {
+		c, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.expr, err)
+		}
+		if got := c.Matches(tt.t); got != tt.match {
+			t.Errorf("cron(%s): Matches(%v) = %v", tt.expr, tt.t, got)
+		}
+	}
+}
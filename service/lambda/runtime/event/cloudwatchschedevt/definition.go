@@ -23,23 +23,26 @@ import (
 
 // Event represents an Amazon CloudWatch Scheduled event.
 type Event struct {
+	// By default, this is set to 0 in all events.
+	Version string `json:"version"`
+
 	// A unique value generated for every event.
-	ID string
+	ID string `json:"id"`
 
 	// The event timestamp, which is the time when the event have been
 	// actually triggered.
-	Time time.Time
+	Time time.Time `json:"time"`
 
 	// The 12-digit number identifying an AWS account.
-	Account string
+	Account string `json:"account"`
 
 	// Identifies the AWS region where the event originated.
-	Region string
+	Region string `json:"region"`
 
 	// A JSON object, whose content is at the discretion of the service
 	// originating the event.
 	// In our case the value is always an empty object.
-	Detail json.RawMessage
+	Detail json.RawMessage `json:"detail"`
 
 	// Identifies, in combination with the source field, the fields and
 	// values that will appear in the detail field.
@@ -50,12 +53,12 @@ type Event struct {
 	// from within AWS will begin with "aws.". Customer-generated events can
 	// have any value here as long as it doesn't begin with "aws.".
 	// In our case the value is always "aws.events".
-	Source string
+	Source string `json:"source"`
 
 	// This JSON array contains ARNs that identify resources that are
 	// involved in the event.
 	// In our case the value is always an Amazon CloudWatch Rule ARN.
-	Resources []string
+	Resources []string `json:"resources"`
 }
 
 // String returns the string representation.
@@ -21,5 +21,7 @@ CloudWatch Events.
 
 This package works only when forwarding the whole event to your Lambda function.
 Otherwise you will have to handle the event manually.
+
+For the other Amazon EventBridge events, see the eventbridgeevt package.
 */
 package cloudwatchschedevt
@@ -0,0 +1,154 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudwatchschedevt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule represents an Amazon CloudWatch Events schedule expression. The
+// times are evaluated in UTC, with a minute precision: seconds are ignored.
+//
+// To tell whether an event has been triggered on time, check that
+// Matches(evt.Time) holds, or compare evt.Time with
+// Next(evt.Time.Add(-time.Minute)) to know how late it is.
+type Schedule interface {
+	// Next returns the first tick of the schedule strictly after the given
+	// time, or the zero time if there is none.
+	Next(after time.Time) time.Time
+
+	// Matches reports whether the time is a tick of the schedule.
+	Matches(t time.Time) bool
+
+	// String returns the schedule expression.
+	String() string
+}
+
+// ParseSchedule parses a rate(value unit) or cron(fields) schedule expression.
+// See also http://docs.aws.amazon.com/AmazonCloudWatch/latest/events/ScheduledEvents.html
+func ParseSchedule(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	switch {
+	case strings.HasPrefix(expr, "rate(") && strings.HasSuffix(expr, ")"):
+		return ParseRate(expr[5 : len(expr)-1])
+	case strings.HasPrefix(expr, "cron(") && strings.HasSuffix(expr, ")"):
+		return ParseCron(expr[5 : len(expr)-1])
+	}
+	return nil, fmt.Errorf("cloudwatchschedevt: invalid schedule expression: %s", expr)
+}
+
+// Rate represents a rate(value unit) schedule expression, which ticks at a
+// regular interval.
+type Rate struct {
+	// The interval between two ticks.
+	Interval time.Duration
+
+	// The time the rule was created, from which the ticks are counted. It
+	// must be set for Next and Matches to be accurate. Since events do not
+	// carry this information, it defaults to January 1, 1970 00:00:00 UTC,
+	// that is ticks are assumed to be aligned on the interval.
+	Start time.Time
+}
+
+var rateUnits = map[string]time.Duration{
+	"minute": time.Minute,
+	"hour":   time.Hour,
+	"day":    24 * time.Hour,
+}
+
+// ParseRate parses the "value unit" content of a rate expression, such as
+// "5 minutes". As Amazon CloudWatch Events does, it expects the singular unit
+// for a value of 1 and the plural one otherwise.
+func ParseRate(s string) (*Rate, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("cloudwatchschedevt: invalid rate expression: %s", s)
+	}
+
+	v, err := strconv.Atoi(fields[0])
+	if err != nil || v <= 0 {
+		return nil, fmt.Errorf("cloudwatchschedevt: invalid rate value: %s", fields[0])
+	}
+	unit := fields[1]
+	if v > 1 {
+		if !strings.HasSuffix(unit, "s") {
+			return nil, fmt.Errorf("cloudwatchschedevt: invalid rate unit: %s", unit)
+		}
+		unit = unit[:len(unit)-1]
+	}
+	d, ok := rateUnits[unit]
+	if !ok {
+		return nil, fmt.Errorf("cloudwatchschedevt: invalid rate unit: %s", fields[1])
+	}
+
+	return &Rate{Interval: time.Duration(v) * d}, nil
+}
+
+// Next returns the first tick of the schedule strictly after the given time.
+func (r *Rate) Next(after time.Time) time.Time {
+	start := r.start()
+	if after.Before(start) {
+		return start
+	}
+	n := after.Sub(start)/r.Interval + 1
+	return start.Add(n * r.Interval)
+}
+
+// Matches reports whether the time is a tick of the schedule.
+func (r *Rate) Matches(t time.Time) bool {
+	t, start := t.UTC().Truncate(time.Minute), r.start()
+	return !t.Before(start) && t.Sub(start)%r.Interval == 0
+}
+
+// String returns the schedule expression.
+func (r *Rate) String() string {
+	unit, d := "minute", time.Minute
+	switch {
+	case r.Interval%(24*time.Hour) == 0:
+		unit, d = "day", 24*time.Hour
+	case r.Interval%time.Hour == 0:
+		unit, d = "hour", time.Hour
+	}
+	v := int64(r.Interval / d)
+	if v > 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("rate(%d %s)", v, unit)
+}
+
+func (r *Rate) start() time.Time {
+	if r.Start.IsZero() {
+		return time.Unix(0, 0).UTC()
+	}
+	return r.Start.UTC().Truncate(time.Minute)
+}
+
+// RuleName returns the name of the rule which triggered the event, from the
+// rule ARN of its resources, or an empty string if there is none.
+func (e *Event) RuleName() string {
+	for _, arn := range e.Resources {
+		if i := strings.Index(arn, ":rule/"); i >= 0 {
+			name := arn[i+len(":rule/"):]
+			// Rules of custom event buses are named bus-name/rule-name.
+			return name[strings.LastIndexByte(name, '/')+1:]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package cloudwatchschedevt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParseSchedule(t *testing.T) {
+	tests := []struct {
+		expr string
+		ok   bool
+	}{
+		{"rate(1 minute)", true},
+		{"rate(5 minutes)", true},
+		{"rate(1 hour)", true},
+		{"rate(12 hours)", true},
+		{"rate(1 day)", true},
+		{"rate(7 days)", true},
+		{"cron(0 10 * * ? *)", true},
+		{"cron(15 12 * * ? *)", true},
+		{"cron(0 18 ? * MON-FRI *)", true},
+		{"cron(0 8 1 * ? *)", true},
+		{"cron(0/15 * * * ? *)", true},
+		{"cron(0/10 * ? * MON-FRI *)", true},
+		{"cron(0/5 8-17 ? * MON-FRI *)", true},
+		{"cron(0 9 ? * 2#1 *)", true},
+		{"rate(1 minutes)", false},
+		{"rate(5 minute)", false},
+		{"rate(0 minutes)", false},
+		{"rate(5 seconds)", false},
+		{"rate(5)", false},
+		{"cron(0 10 * * * *)", false},
+		{"cron(0 10 ? * ? *)", false},
+		{"cron(0 10 * * ?)", false},
+		{"cron(60 10 * * ? *)", false},
+		{"cron(0 10 * * ? 1969)", false},
+		{"cron(0 10 * * ? 2200)", false},
+		{"cron(0 10 ? * 2#6 *)", false},
+		{"every 5 minutes", false},
+	}
+
+	for _, tt := range tests {
+		s, err := ParseSchedule(tt.expr)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: unexpected error: %v", tt.expr, err)
+			continue
+		}
+		if err == nil && s.String() != tt.expr {
+			t.Errorf("%s: got %s", tt.expr, s)
+		}
+	}
+}
+
+func TestRate(t *testing.T) {
+	tests := []struct {
+		expr  string
+		start time.Time
+		t     time.Time
+		match bool
+		next  time.Time
+	}{
+		{"5 minutes", time.Time{}, date("2017-03-17T12:05:00Z"), true, date("2017-03-17T12:10:00Z")},
+		{"5 minutes", time.Time{}, date("2017-03-17T12:05:03Z"), true, date("2017-03-17T12:10:00Z")},
+		{"5 minutes", time.Time{}, date("2017-03-17T12:05:59.999Z"), true, date("2017-03-17T12:10:00Z")},
+		{"5 minutes", time.Time{}, date("2017-03-17T12:06:00Z"), false, date("2017-03-17T12:10:00Z")},
+		{"5 minutes", date("2017-03-17T08:02:30Z"), date("2017-03-17T08:07:10Z"), true, date("2017-03-17T08:12:00Z")},
+		{"5 minutes", date("2017-03-17T08:02:30Z"), date("2017-03-17T08:05:00Z"), false, date("2017-03-17T08:07:00Z")},
+		{"5 minutes", date("2017-03-17T08:02:30Z"), date("2017-03-17T07:57:00Z"), false, date("2017-03-17T08:02:00Z")},
+		{"1 hour", time.Time{}, date("2017-03-17T12:00:42Z"), true, date("2017-03-17T13:00:00Z")},
+		{"2 days", time.Time{}, date("2017-03-17T00:00:00Z"), true, date("2017-03-19T00:00:00Z")},
+		{"2 days", time.Time{}, date("2017-03-18T00:00:00Z"), false, date("2017-03-19T00:00:00Z")},
+		{"1 day", date("2017-03-17T08:00:00+02:00"), date("2017-03-20T06:00:00Z"), true, date("2017-03-21T06:00:00Z")},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseRate(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.Start = tt.start
+		if got := r.Matches(tt.t); got != tt.match {
+			t.Errorf("rate(%s) from %v: Matches(%v) = %v", tt.expr, tt.start, tt.t, got)
+		}
+		if got := r.Next(tt.t); !got.Equal(tt.next) {
+			t.Errorf("rate(%s) from %v: Next(%v) = %v, want %v", tt.expr, tt.start, tt.t, got, tt.next)
+		}
+	}
+}
+
+func TestRuleName(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{`{"resources":["arn:aws:events:us-east-1:123456789012:rule/my-scheduled-rule"]}`, "my-scheduled-rule"},
+		{`{"resources":["arn:aws:events:us-east-1:123456789012:rule/my-bus/my-rule"]}`, "my-rule"},
+		{`{"resources":[]}`, ""},
+	}
+
+	for _, tt := range tests {
+		var evt Event
+		if err := json.Unmarshal([]byte(tt.data), &evt); err != nil {
+			t.Fatal(err)
+		}
+		if got := evt.RuleName(); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.data, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package codepipelineevt
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// The AWS CodePipeline API constants used to sign and route the requests.
+const (
+	serviceName  = "codepipeline"
+	targetPrefix = "CodePipeline_20150709."
+	contentType  = "application/x-amz-json-1.1"
+)
+
+// APIError represents an error returned by the AWS CodePipeline API.
+type APIError struct {
+	// The HTTP status code of the response.
+	StatusCode int
+
+	// The error code, such as "JobNotFoundException".
+	Code string
+
+	// The error message.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("codepipelineevt: %s: %s (status %d)", e.Code, e.Message, e.StatusCode)
+}
+
+// HTTPReporter is a JobReporter calling the AWS CodePipeline API over HTTP,
+// with requests signed with AWS Signature Version 4.
+type HTTPReporter struct {
+	// The AWS region of the pipeline.
+	Region string
+
+	// The credentials used to sign the requests. Note that the artifact
+	// credentials of the job cannot be used, since they only give access
+	// to the artifacts.
+	Credentials *AWSSessionCredentials
+
+	// The endpoint of the AWS CodePipeline API.
+	// If empty, the regional endpoint is used.
+	Endpoint string
+
+	// The client used to send the requests.
+	// If nil, http.DefaultClient is used.
+	Client *http.Client
+
+	// The current time, used to sign the requests.
+	// If nil, time.Now is used.
+	Now func() time.Time
+}
+
+// NewHTTPReporter returns an HTTPReporter with the region and credentials
+// AWS Lambda provides through the environment of the function.
+func NewHTTPReporter() *HTTPReporter {
+	return &HTTPReporter{
+		Region: os.Getenv("AWS_REGION"),
+		Credentials: &AWSSessionCredentials{
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		},
+	}
+}
+
+// ReportSuccess calls the PutJobSuccessResult action.
+func (r *HTTPReporter) ReportSuccess(ctx context.Context, jobID string, res *SuccessResult) error {
+	in := struct {
+		JobID string `json:"jobId"`
+		*SuccessResult
+	}{jobID, res}
+	if in.SuccessResult == nil {
+		in.SuccessResult = &SuccessResult{}
+	}
+	return r.do(ctx, "PutJobSuccessResult", &in)
+}
+
+// ReportFailure calls the PutJobFailureResult action.
+func (r *HTTPReporter) ReportFailure(ctx context.Context, jobID string, res *FailureResult) error {
+	if res == nil {
+		return errors.New("codepipelineevt: nil failure result")
+	}
+	in := struct {
+		JobID          string         `json:"jobId"`
+		FailureDetails *FailureResult `json:"failureDetails"`
+	}{jobID, res}
+	return r.do(ctx, "PutJobFailureResult", &in)
+}
+
+func (r *HTTPReporter) do(ctx context.Context, action string, in interface{}) error {
+	if r.Credentials == nil {
+		return errors.New("codepipelineevt: no credentials")
+	}
+
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	endpoint := r.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com/", serviceName, r.Region)
+	}
+	req, err := http.NewRequest("POST", endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Amz-Target", targetPrefix+action)
+
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+	sign(req, body, r.Credentials, r.Region, serviceName, now())
+
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var out struct {
+		Type    string `json:"__type"`
+		Message string `json:"message"`
+	}
+	json.Unmarshal(data, &out)
+	// The error type may be prefixed with its namespace.
+	code := out.Type[strings.LastIndexByte(out.Type, '#')+1:]
+	if code == "" {
+		code = http.StatusText(resp.StatusCode)
+	}
+	return &APIError{StatusCode: resp.StatusCode, Code: code, Message: out.Message}
+}
+
+// sign adds the AWS Signature Version 4 headers to the request.
+// See http://docs.aws.amazon.com/general/latest/gr/sigv4_signing.html
+func sign(req *http.Request, body []byte, creds *AWSSessionCredentials, region, service string, t time.Time) {
+	amzDate := t.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		headers[strings.ToLower(k)] = strings.TrimSpace(strings.Join(v, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders bytes.Buffer
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		hexSHA256(body),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
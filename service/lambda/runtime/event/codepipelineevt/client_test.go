@@ -0,0 +1,291 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package codepipelineevt
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The credentials and time of the AWS Signature Version 4 test suite.
+var (
+	testCredentials = &AWSSessionCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+	testTime = time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC)
+)
+
+func TestSign(t *testing.T) {
+	// The get-vanilla and post-vanilla cases of the AWS Signature Version 4
+	// test suite.
+	tests := []struct {
+		method, signature string
+	}{
+		{"GET", "5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"},
+		{"POST", "5da7c1a2acd57cee7505fc6676e4e544621c30862966e37dddb68e92efbe5d6b"},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest(test.method, "https://example.amazonaws.com/", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sign(req, nil, testCredentials, "us-east-1", "service", testTime)
+
+		want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+			"SignedHeaders=host;x-amz-date, Signature=" + test.signature
+		if got := req.Header.Get("Authorization"); got != want {
+			t.Errorf("%s: Authorization = %q, want %q", test.method, got, want)
+		}
+		if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+			t.Errorf("%s: X-Amz-Date = %q", test.method, got)
+		}
+	}
+}
+
+func TestSignSessionToken(t *testing.T) {
+	req, err := http.NewRequest("POST", "https://example.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds := *testCredentials
+	creds.SessionToken = "token"
+	sign(req, nil, &creds, "us-east-1", "service", testTime)
+
+	if got := req.Header.Get("X-Amz-Security-Token"); got != "token" {
+		t.Errorf("X-Amz-Security-Token = %q", got)
+	}
+	if got := req.Header.Get("Authorization"); !strings.Contains(got, "SignedHeaders=host;x-amz-date;x-amz-security-token,") {
+		t.Errorf("Authorization = %q, want security token signed", got)
+	}
+}
+
+type request struct {
+	target string
+	body   map[string]interface{}
+}
+
+// serve starts a server recording the requests and replying with the given
+// status and body. The server must be closed by the caller.
+func serve(t *testing.T, status int, body string) (*httptest.Server, *HTTPReporter, *[]request) {
+	var reqs []request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" {
+			t.Errorf("method = %s, want POST", r.Method)
+		}
+		if got := r.Header.Get("Content-Type"); got != contentType {
+			t.Errorf("Content-Type = %q, want %q", got, contentType)
+		}
+		if got := r.Header.Get("Authorization"); !strings.HasPrefix(got, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/codepipeline/aws4_request, ") {
+			t.Errorf("Authorization = %q", got)
+		}
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := request{target: r.Header.Get("X-Amz-Target")}
+		if err := json.Unmarshal(data, &req.body); err != nil {
+			t.Errorf("invalid body %s: %v", data, err)
+		}
+		reqs = append(reqs, req)
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+
+	return srv, &HTTPReporter{
+		Region:      "us-east-1",
+		Credentials: testCredentials,
+		Endpoint:    srv.URL,
+		Now:         func() time.Time { return testTime },
+	}, &reqs
+}
+
+func TestHTTPReporterSuccess(t *testing.T) {
+	srv, r, reqs := serve(t, http.StatusOK, "{}")
+	defer srv.Close()
+
+	created := time.Date(2017, 3, 20, 8, 53, 20, 123e6, time.UTC)
+	percent := 100
+	err := r.ReportSuccess(context.Background(), "11111111-abcd-1111-abcd-111111abcdef", &SuccessResult{
+		ContinuationToken: "token",
+		ExecutionDetails: &ExecutionDetails{
+			Summary:             "done",
+			ExternalExecutionID: "execution",
+			PercentComplete:     &percent,
+		},
+		OutputVariables: map[string]string{"status": "done"},
+		CurrentRevision: &CurrentRevision{
+			Revision:         "revision",
+			ChangeIdentifier: "change",
+			Created:          created,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*reqs) != 1 {
+		t.Fatalf("got %d requests, want 1", len(*reqs))
+	}
+
+	req := (*reqs)[0]
+	if req.target != "CodePipeline_20150709.PutJobSuccessResult" {
+		t.Errorf("X-Amz-Target = %q", req.target)
+	}
+	want := map[string]interface{}{
+		"jobId":             "11111111-abcd-1111-abcd-111111abcdef",
+		"continuationToken": "token",
+		"executionDetails": map[string]interface{}{
+			"summary":             "done",
+			"externalExecutionId": "execution",
+			"percentComplete":     100.0,
+		},
+		"outputVariables": map[string]interface{}{"status": "done"},
+		"currentRevision": map[string]interface{}{
+			"revision":         "revision",
+			"changeIdentifier": "change",
+			"created":          1490000000.123,
+		},
+	}
+	if !reflect.DeepEqual(req.body, want) {
+		t.Errorf("body = %v, want %v", req.body, want)
+	}
+
+	data, err := json.Marshal(req.body["currentRevision"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rev CurrentRevision
+	if err := json.Unmarshal(data, &rev); err != nil {
+		t.Fatal(err)
+	}
+	if !rev.Created.Equal(created) {
+		t.Errorf("created = %v, want %v", rev.Created, created)
+	}
+}
+
+func TestHTTPReporterSuccessEmpty(t *testing.T) {
+	srv, r, reqs := serve(t, http.StatusOK, "{}")
+	defer srv.Close()
+
+	if err := r.ReportSuccess(context.Background(), "job", nil); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"jobId": "job"}
+	if got := (*reqs)[0].body; !reflect.DeepEqual(got, want) {
+		t.Errorf("body = %v, want %v", got, want)
+	}
+}
+
+func TestHTTPReporterFailure(t *testing.T) {
+	srv, r, reqs := serve(t, http.StatusOK, "{}")
+	defer srv.Close()
+
+	err := r.ReportFailure(context.Background(), "job", &FailureResult{
+		Type:                FailureConfigurationError,
+		Message:             "missing parameter",
+		ExternalExecutionID: "execution",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := (*reqs)[0]
+	if req.target != "CodePipeline_20150709.PutJobFailureResult" {
+		t.Errorf("X-Amz-Target = %q", req.target)
+	}
+	want := map[string]interface{}{
+		"jobId": "job",
+		"failureDetails": map[string]interface{}{
+			"type":                "ConfigurationError",
+			"message":             "missing parameter",
+			"externalExecutionId": "execution",
+		},
+	}
+	if !reflect.DeepEqual(req.body, want) {
+		t.Errorf("body = %v, want %v", req.body, want)
+	}
+}
+
+func TestHTTPReporterError(t *testing.T) {
+	tests := []struct {
+		status int
+		body   string
+		want   APIError
+	}{
+		{
+			http.StatusBadRequest,
+			`{"__type":"com.amazonaws.codepipeline#JobNotFoundException","message":"job not found"}`,
+			APIError{http.StatusBadRequest, "JobNotFoundException", "job not found"},
+		},
+		{
+			http.StatusBadRequest,
+			`{"__type":"InvalidJobStateException","message":"job already completed"}`,
+			APIError{http.StatusBadRequest, "InvalidJobStateException", "job already completed"},
+		},
+		{
+			http.StatusInternalServerError,
+			"",
+			APIError{http.StatusInternalServerError, "Internal Server Error", ""},
+		},
+	}
+	for _, test := range tests {
+		srv, r, _ := serve(t, test.status, test.body)
+		err := r.ReportSuccess(context.Background(), "job", nil)
+		srv.Close()
+
+		got, ok := err.(*APIError)
+		if !ok {
+			t.Errorf("%s: err = %v, want *APIError", test.body, err)
+			continue
+		}
+		if *got != test.want {
+			t.Errorf("%s: err = %+v, want %+v", test.body, *got, test.want)
+		}
+	}
+
+	err := &APIError{http.StatusBadRequest, "JobNotFoundException", "job not found"}
+	if got, want := err.Error(), "codepipelineevt: JobNotFoundException: job not found (status 400)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestHTTPReporterInvalid(t *testing.T) {
+	srv, r, reqs := serve(t, http.StatusOK, "{}")
+	defer srv.Close()
+
+	err := r.ReportFailure(context.Background(), "job", nil)
+	if err == nil || err.Error() != "codepipelineevt: nil failure result" {
+		t.Errorf("nil failure result: err = %v", err)
+	}
+
+	r.Credentials = nil
+	err = r.ReportSuccess(context.Background(), "job", nil)
+	if err == nil || err.Error() != "codepipelineevt: no credentials" {
+		t.Errorf("no credentials: err = %v", err)
+	}
+
+	if len(*reqs) != 0 {
+		t.Errorf("got %d requests, want 0", len(*reqs))
+	}
+}
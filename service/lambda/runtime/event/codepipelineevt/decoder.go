@@ -16,7 +16,12 @@
 
 package codepipelineevt
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+)
 
 // UnmarshalJSON interprets data as map in order to extract the incoming
 // "CodePipeline.job" key and make the Event struct json-tag-less.
@@ -28,3 +33,69 @@ func (e *Event) UnmarshalJSON(data []byte) error {
 	e.Job = t["CodePipeline.job"]
 	return nil
 }
+
+// MarshalJSON reverts the effect of UnmarshalJSON by nesting the job under the
+// "CodePipeline.job" key.
+func (e *Event) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]*Job{"CodePipeline.job": e.Job})
+}
+
+type currentRevisionAlias CurrentRevision
+
+type timestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON interprets the data as a decimal number of seconds elapsed
+// since January 1, 1970 00:00:00 UTC. It then sets *t to a copy of the
+// interpreted time, rounded to the millisecond to absorb the floating point
+// error.
+func (t *timestamp) UnmarshalJSON(data []byte) error {
+	v, err := strconv.ParseFloat(string(data), 64)
+	if err != nil {
+		return err
+	}
+
+	ms := int64(math.Floor(v*1e3 + 0.5))
+	t.Time = time.Unix(0, ms*int64(time.Millisecond))
+	return nil
+}
+
+// MarshalJSON returns t as a decimal number of seconds elapsed since
+// January 1, 1970 00:00:00 UTC, with a millisecond precision.
+func (t timestamp) MarshalJSON() ([]byte, error) {
+	v := float64(t.UnixNano()/int64(time.Millisecond)) / 1e3
+	return []byte(strconv.FormatFloat(v, 'f', -1, 64)), nil
+}
+
+type jsonCurrentRevision struct {
+	*currentRevisionAlias
+	Created *timestamp `json:"created,omitempty"`
+}
+
+// UnmarshalJSON interprets data as a CurrentRevision with a special timestamp.
+// It then leverages type aliasing and struct embedding to fill
+// CurrentRevision with an usual time.Time.
+func (r *CurrentRevision) UnmarshalJSON(data []byte) error {
+	jr := jsonCurrentRevision{currentRevisionAlias: (*currentRevisionAlias)(r)}
+	if err := json.Unmarshal(data, &jr); err != nil {
+		return err
+	}
+
+	if jr.Created != nil {
+		r.Created = jr.Created.Time
+	}
+
+	return nil
+}
+
+// MarshalJSON reverts the effect of type aliasing and struct embedding used
+// during the marshalling step to make the pattern seamless. The creation time
+// is omitted when zero.
+func (r *CurrentRevision) MarshalJSON() ([]byte, error) {
+	jr := jsonCurrentRevision{currentRevisionAlias: (*currentRevisionAlias)(r)}
+	if !r.Created.IsZero() {
+		jr.Created = &timestamp{r.Created}
+	}
+	return json.Marshal(&jr)
+}
@@ -25,13 +25,13 @@ import "encoding/json"
 // CodePipeline.
 type AWSSessionCredentials struct {
 	// The access key for the session.
-	AccessKeyID string
+	AccessKeyID string `json:"accessKeyId"`
 
 	// The secret access key for the session.
-	SecretAccessKey string
+	SecretAccessKey string `json:"secretAccessKey"`
 
 	// The token for the session.
-	SessionToken string
+	SessionToken string `json:"sessionToken"`
 }
 
 // ActionConfiguration represents information about an action configuration.
@@ -39,83 +39,98 @@ type ActionConfiguration struct {
 	// The configuration data for the action.
 	Configuration *struct {
 		// AWS Lambda function name.
-		FunctionName string
+		FunctionName string `json:"FunctionName"`
 
 		// An arbitrary string of user formatted configuration for the
 		// AWS Lambda function to complete the job.
-		UserParameters string
-	}
+		UserParameters string `json:"UserParameters"`
+	} `json:"configuration"`
 }
 
 // S3ArtifactLocation represents the location of the Amazon S3 bucket that
 // contains a revision.
 type S3ArtifactLocation struct {
 	// The name of the Amazon S3 bucket.
-	BucketName string
+	BucketName string `json:"bucketName"`
 
 	// The key of the object in the Amazon S3 bucket, which uniquely
 	// identifies the object in the bucket.
-	ObjectKey string
+	ObjectKey string `json:"objectKey"`
 }
 
 // ArtifactLocation represents information about the location of an artifact.
 type ArtifactLocation struct {
 	// The type of artifact in the location.
-	Type string
+	Type string `json:"type"`
 
 	// The Amazon S3 bucket that contains the artifact.
-	S3Location *S3ArtifactLocation
+	S3Location *S3ArtifactLocation `json:"s3Location"`
 }
 
 // Artifact represents information about an artifact that will be worked upon
 // by actions in the pipeline.
 type Artifact struct {
 	// The artifact's name.
-	Name string
+	Name string `json:"name"`
 
 	// The artifact's revision ID.
 	// Depending on the type of object, this could be a commit ID (GitHub)
 	// or a revision ID (Amazon S3).
-	Revision string
+	Revision string `json:"revision"`
 
 	// The location of an artifact.
-	Location *ArtifactLocation
+	Location *ArtifactLocation `json:"location"`
+}
+
+// EncryptionKey represents the key used to encrypt and decrypt data in the
+// artifact store for the pipeline.
+type EncryptionKey struct {
+	// The ID or the ARN of the key. For an AWS KMS key, this is the key ID
+	// or the key ARN.
+	ID string `json:"id"`
+
+	// The type of encryption key. Currently the only valid value is KMS.
+	Type string `json:"type"`
 }
 
 // Data represents additional information about an AWS CodePipeline job required
 // for the AWS Lambda function to complete the job.
 type Data struct {
 	// Information about an action configuration.
-	ActionConfiguration *ActionConfiguration
+	ActionConfiguration *ActionConfiguration `json:"actionConfiguration"`
 
 	// A system-generated token, such as an AWS CodeDeploy deployment ID,
 	// that a job requires in order to continue the job asynchronously.
-	ContinuationToken string
+	ContinuationToken string `json:"continuationToken,omitempty"`
 
 	// An AWS session credentials object.
 	// These credentials are temporary credentials that are issued by AWS
 	// Secure Token Service (STS). They can be used to access input and
 	// output artifacts in the Amazon S3 bucket used to store artifact for
 	// the pipeline in AWS CodePipeline.
-	ArtifactCredentials *AWSSessionCredentials
+	ArtifactCredentials *AWSSessionCredentials `json:"artifactCredentials"`
 
 	// The artifact supplied to the job.
-	InputArtifacts []*Artifact
+	InputArtifacts []*Artifact `json:"inputArtifacts"`
 
 	// The output of the job.
-	OutputArtifacts []*Artifact
+	OutputArtifacts []*Artifact `json:"outputArtifacts"`
+
+	// The encryption key used to encrypt and decrypt data in the artifact
+	// store for the pipeline. Absent when the default key is used.
+	EncryptionKey *EncryptionKey `json:"encryptionKey,omitempty"`
 }
 
 // Job represents information about the details of an AWS CodePipeline job.
 type Job struct {
 	// The unique system-generated ID of the job.
-	ID string
+	ID string `json:"id"`
 
 	// The ID of the AWS account to use when performing the job.
-	AccountID string
+	AccountID string `json:"accountId"`
 
 	// Additional information about the job.
-	Data *Data
+	Data *Data `json:"data"`
 }
 
 // Event represents an AWS CodePipeline event.
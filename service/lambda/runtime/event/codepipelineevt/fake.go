@@ -0,0 +1,76 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package codepipelineevt
+
+import (
+	"context"
+	"sync"
+)
+
+// FakeReporter is an in-memory JobReporter, for testing the functions which
+// report the result of AWS CodePipeline jobs.
+type FakeReporter struct {
+	// Err, if not nil, is returned by every report, which is then not
+	// recorded.
+	Err error
+
+	mu        sync.Mutex
+	successes map[string][]*SuccessResult
+	failures  map[string][]*FailureResult
+}
+
+// ReportSuccess records the success of the job.
+func (f *FakeReporter) ReportSuccess(ctx context.Context, jobID string, r *SuccessResult) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return f.Err
+	}
+	if f.successes == nil {
+		f.successes = make(map[string][]*SuccessResult)
+	}
+	f.successes[jobID] = append(f.successes[jobID], r)
+	return nil
+}
+
+// ReportFailure records the failure of the job.
+func (f *FakeReporter) ReportFailure(ctx context.Context, jobID string, r *FailureResult) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.Err != nil {
+		return f.Err
+	}
+	if f.failures == nil {
+		f.failures = make(map[string][]*FailureResult)
+	}
+	f.failures[jobID] = append(f.failures[jobID], r)
+	return nil
+}
+
+// Successes returns the successes reported for the job, in order.
+func (f *FakeReporter) Successes(jobID string) []*SuccessResult {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*SuccessResult(nil), f.successes[jobID]...)
+}
+
+// Failures returns the failures reported for the job, in order.
+func (f *FakeReporter) Failures(jobID string) []*FailureResult {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*FailureResult(nil), f.failures[jobID]...)
+}
@@ -0,0 +1,105 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package codepipelineevt
+
+import (
+	"context"
+	"time"
+)
+
+// FailureType is the category of a job failure.
+type FailureType string
+
+// The categories of job failures.
+const (
+	FailureJobFailed           FailureType = "JobFailed"
+	FailureConfigurationError  FailureType = "ConfigurationError"
+	FailurePermissionError     FailureType = "PermissionError"
+	FailureRevisionOutOfSync   FailureType = "RevisionOutOfSync"
+	FailureRevisionUnavailable FailureType = "RevisionUnavailable"
+	FailureSystemUnavailable   FailureType = "SystemUnavailable"
+)
+
+// ExecutionDetails provides the details of the actions taken by the job.
+type ExecutionDetails struct {
+	// The summary of the current status of the actions.
+	Summary string `json:"summary,omitempty"`
+
+	// The system-generated unique ID of this action used to identify this
+	// job worker in any external systems, such as AWS CodeDeploy.
+	ExternalExecutionID string `json:"externalExecutionId,omitempty"`
+
+	// The percentage of work completed on the action, from 0 to 100.
+	PercentComplete *int `json:"percentComplete,omitempty"`
+}
+
+// CurrentRevision represents the revision of the artifact the job worked on.
+type CurrentRevision struct {
+	// The revision ID of the current version of the artifact.
+	Revision string `json:"revision"`
+
+	// The change identifier for the current revision.
+	ChangeIdentifier string `json:"changeIdentifier"`
+
+	// The date and time the most recent revision of the artifact was
+	// created.
+	Created time.Time `json:"-"`
+
+	// The summary of the most recent revision of the artifact.
+	RevisionSummary string `json:"revisionSummary,omitempty"`
+}
+
+// SuccessResult represents the successful result of a job.
+type SuccessResult struct {
+	// A token to pass back to the next invocation of the function, for
+	// actions which take longer than a single invocation. AWS CodePipeline
+	// then invokes the function again with this token in Data. If empty,
+	// the action is complete.
+	ContinuationToken string `json:"continuationToken,omitempty"`
+
+	// The details of the actions taken by the job.
+	ExecutionDetails *ExecutionDetails `json:"executionDetails,omitempty"`
+
+	// The variables the action makes available to the following actions
+	// of the pipeline.
+	OutputVariables map[string]string `json:"outputVariables,omitempty"`
+
+	// The revision of the artifact the job worked on.
+	CurrentRevision *CurrentRevision `json:"currentRevision,omitempty"`
+}
+
+// FailureResult represents the failed result of a job.
+type FailureResult struct {
+	// The category of the failure.
+	Type FailureType `json:"type"`
+
+	// The message about the failure.
+	Message string `json:"message"`
+
+	// The external ID of the run of the action that failed.
+	ExternalExecutionID string `json:"externalExecutionId,omitempty"`
+}
+
+// JobReporter reports the result of AWS CodePipeline jobs, so that the
+// pipeline can move on to the next action.
+type JobReporter interface {
+	// ReportSuccess reports the success of the job with the given ID.
+	ReportSuccess(ctx context.Context, jobID string, r *SuccessResult) error
+
+	// ReportFailure reports the failure of the job with the given ID.
+	ReportFailure(ctx context.Context, jobID string, r *FailureResult) error
+}
@@ -21,40 +21,40 @@ import "encoding/json"
 // DatasetRecord contains information about each record in a data set.
 type DatasetRecord struct {
 	// Old value of the record.
-	OldValue string
+	OldValue string `json:"oldValue"`
 
 	// New value of the record.
-	NewValue string
+	NewValue string `json:"newValue"`
 
 	// The operation associated with the record:
 	// - replace: if a record is created or updated.
 	// - remove: if a record is deleted.
-	OP string
+	OP string `json:"op"`
 }
 
 // Event represents an Amazon Cognito Sync event.
 type Event struct {
 	// The version of the event.
-	Version int
+	Version int `json:"version"`
 
 	// The identity pool ID associated with the dataset.
-	IdentityPoolID string
+	IdentityPoolID string `json:"identityPoolId"`
 
 	// The actual identity ID from Amazon Cognito.
-	IdentityID string
+	IdentityID string `json:"identityId"`
 
 	// The region in which dataset resides.
-	Region string
+	Region string `json:"region"`
 
 	// The dataset name of the event.
-	DatasetName string
+	DatasetName string `json:"datasetName"`
 
 	// The map of dataset records for the event.
-	DatasetRecords map[string]*DatasetRecord
+	DatasetRecords map[string]*DatasetRecord `json:"datasetRecords"`
 
 	// The event type.
 	// In our case the value is always "SyncTrigger".
-	EventType string
+	EventType string `json:"eventType"`
 }
 
 // String returns the string representation.
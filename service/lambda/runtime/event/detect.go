@@ -0,0 +1,112 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package event
+
+import "encoding/json"
+
+// Source identifies the AWS service an event originates from.
+type Source string
+
+// The event sources Detect is able to identify.
+const (
+	Unknown              Source = ""
+	APIGatewayProxy      Source = "apigatewayproxy"
+	APIGatewayAuthorizer Source = "apigatewayauthorizer"
+	CloudFormation       Source = "cloudformation"
+	CloudWatchLogs       Source = "cloudwatchlogs"
+	CloudWatchScheduled  Source = "cloudwatchsched"
+	CodePipeline         Source = "codepipeline"
+	CognitoSync          Source = "cognitosync"
+	EventBridge          Source = "eventbridge"
+	DynamoDBStreams      Source = "dynamodbstreams"
+	KinesisFirehose      Source = "kinesisfirehose"
+	KinesisStreams       Source = "kinesisstreams"
+	S3                   Source = "s3"
+	SES                  Source = "ses"
+	SNS                  Source = "sns"
+	SQS                  Source = "sqs"
+)
+
+// recordSources maps the event source of a record to its Source.
+var recordSources = map[string]Source{
+	"aws:s3":       S3,
+	"aws:sns":      SNS,
+	"aws:ses":      SES,
+	"aws:kinesis":  KinesisStreams,
+	"aws:dynamodb": DynamoDBStreams,
+	"aws:sqs":      SQS,
+}
+
+// Detect identifies the source of the raw JSON event from its shape. It
+// returns Unknown if the event is not a JSON object or does not look like any
+// supported event.
+func Detect(data []byte) Source {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
+		return Unknown
+	}
+
+	has := func(keys ...string) bool {
+		for _, k := range keys {
+			if _, ok := top[k]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+	str := func(key string) string {
+		var s string
+		json.Unmarshal(top[key], &s)
+		return s
+	}
+
+	switch {
+	case has("Records"):
+		var recs []map[string]json.RawMessage
+		if err := json.Unmarshal(top["Records"], &recs); err != nil || len(recs) == 0 {
+			return Unknown
+		}
+		for _, k := range []string{"eventSource", "EventSource"} {
+			var s string
+			json.Unmarshal(recs[0][k], &s)
+			if src, ok := recordSources[s]; ok {
+				return src
+			}
+		}
+	case has("awslogs"):
+		return CloudWatchLogs
+	case has("CodePipeline.job"):
+		return CodePipeline
+	case has("detail-type", "source"):
+		if str("detail-type") == "Scheduled Event" && str("source") == "aws.events" {
+			return CloudWatchScheduled
+		}
+		return EventBridge
+	case has("RequestType", "ResponseURL", "StackId"):
+		return CloudFormation
+	case has("datasetName", "datasetRecords") && str("eventType") == "SyncTrigger":
+		return CognitoSync
+	case has("invocationId", "deliveryStreamArn", "records"):
+		return KinesisFirehose
+	case has("type", "methodArn"):
+		return APIGatewayAuthorizer
+	case has("httpMethod", "resource", "requestContext"):
+		return APIGatewayProxy
+	}
+
+	return Unknown
+}
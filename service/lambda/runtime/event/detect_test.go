@@ -0,0 +1,90 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package event
+
+import (
+	"io/ioutil"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		file string
+		want Source
+	}{
+		{"apigatewayproxyevt/testdata/event-proxy.json", APIGatewayProxy},
+		{"apigatewayproxyevt/testdata/event-cognito-authorizer.json", APIGatewayProxy},
+		{"apigatewayauthorizerevt/testdata/event-token.json", APIGatewayAuthorizer},
+		{"testdata/apigatewayauthorizer-request.json", APIGatewayAuthorizer},
+		{"cloudformationevt/testdata/event-create.json", CloudFormation},
+		{"cloudformationevt/testdata/event-update.json", CloudFormation},
+		{"testdata/cloudwatchlogs.json", CloudWatchLogs},
+		{"cloudwatchschedevt/testdata/event-scheduled.json", CloudWatchScheduled},
+		{"testdata/eventbridge.json", EventBridge},
+		{"codepipelineevt/testdata/event-job.json", CodePipeline},
+		{"cognitosyncevt/testdata/event-sync-trigger.json", CognitoSync},
+		{"dynamodbstreamsevt/testdata/event-insert-modify-remove.json", DynamoDBStreams},
+		{"kinesisfirehoseevt/testdata/input-direct-put.json", KinesisFirehose},
+		{"kinesisstreamsevt/testdata/event-records.json", KinesisStreams},
+		{"s3evt/testdata/event-put.json", S3},
+		{"sesevt/testdata/event-receipt.json", SES},
+		{"snsevt/testdata/event-notification.json", SNS},
+		{"testdata/sqs.json", SQS},
+	}
+
+	for _, tt := range tests {
+		data, err := ioutil.ReadFile(tt.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Detect(data); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestDetectUnknown(t *testing.T) {
+	for _, data := range []string{
+		``,
+		`not json`,
+		`null`,
+		`[]`,
+		`"Records"`,
+		`{}`,
+		`{"foo":"bar"}`,
+		`{"Records":[]}`,
+		`{"Records":{}}`,
+		`{"Records":[{"eventSource":"aws:unknown"}]}`,
+		`{"datasetName":"d","datasetRecords":{},"eventType":"Other"}`,
+		`{"httpMethod":"GET","resource":"/"}`,
+	} {
+		if got := Detect([]byte(data)); got != Unknown {
+			t.Errorf("%s: got %q, want unknown", data, got)
+		}
+	}
+}
+
+func TestDetectEventBridge(t *testing.T) {
+	for _, data := range []string{
+		`{"detail-type":"Scheduled Event","source":"custom.app","detail":{}}`,
+		`{"detail-type":"Order Placed","source":"com.example.orders","detail":{"orderId":"1001"}}`,
+	} {
+		if got := Detect([]byte(data)); got != EventBridge {
+			t.Errorf("%s: got %q, want %q", data, got, EventBridge)
+		}
+	}
+}
@@ -18,6 +18,10 @@
 Package event provides type definitions and helpers to deal with AWS Lambda
 event source mapping.
 
+Detect identifies the source of a raw JSON event from its shape, and Mux routes
+raw JSON events to handlers typed after their source, for functions fed by
+several triggers.
+
 Take a tour at https://github.com/eawsy/aws-lambda-go-event
 */
 package event
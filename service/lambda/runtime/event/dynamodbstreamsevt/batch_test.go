@@ -0,0 +1,71 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package dynamodbstreamsevt
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newTestEvent(seqs ...string) *Event {
+	e := &Event{}
+	for _, seq := range seqs {
+		e.Records = append(e.Records, &EventRecord{DynamoDB: &Record{SequenceNumber: seq}})
+	}
+	return e
+}
+
+func TestProcess(t *testing.T) {
+	var called []string
+	resp := newTestEvent("1", "2", "3").Process(context.Background(), func(ctx context.Context, rec *EventRecord) error {
+		called = append(called, rec.DynamoDB.SequenceNumber)
+		if rec.DynamoDB.SequenceNumber == "2" {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	if want := []string{"1", "2"}; !reflect.DeepEqual(called, want) {
+		t.Errorf("got calls %v, want %v", called, want)
+	}
+	if s := resp.String(); s != `{"batchItemFailures":[{"itemIdentifier":"2"}]}` {
+		t.Errorf("unexpected response: %s", s)
+	}
+
+	resp = newTestEvent("1", "2", "3").Process(context.Background(), func(ctx context.Context, rec *EventRecord) error {
+		return nil
+	})
+	if s := resp.String(); s != `{"batchItemFailures":[]}` {
+		t.Errorf("unexpected response: %s", s)
+	}
+}
+
+func TestProcessMissingRecord(t *testing.T) {
+	e := newTestEvent("1")
+	e.Records = append(e.Records, &EventRecord{}, nil)
+
+	resp := e.Process(context.Background(), func(ctx context.Context, rec *EventRecord) error {
+		_ = rec.DynamoDB.SequenceNumber
+		return nil
+	})
+
+	if s := resp.String(); s != `{"batchItemFailures":[{"itemIdentifier":""}]}` {
+		t.Errorf("unexpected response: %s", s)
+	}
+}
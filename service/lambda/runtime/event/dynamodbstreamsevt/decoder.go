@@ -18,7 +18,9 @@ package dynamodbstreamsevt
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -28,11 +30,42 @@ type timestamp struct {
 	time.Time
 }
 
-// UnmarshalJSON interprets the data as a float64 number, with the integer parts
+// UnmarshalJSON interprets the data as a decimal number, with the integer parts
 // being the number of seconds elapsed since January 1, 1970 00:00:00 UTC and
 // the fractional part being millisecond offset within the second. It then sets
 // *t to a copy of the interpreted time.
+// The fractional part is read as is to avoid any floating point rounding.
 func (t *timestamp) UnmarshalJSON(data []byte) error {
+	s, frac := string(data), ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s, frac = s[:i], s[i+1:]
+	}
+	if len(frac) > 9 {
+		frac = frac[:9]
+	}
+
+	sec, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return t.unmarshalFloat(data)
+	}
+	var nsec int64
+	if frac != "" {
+		nsec, err = strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64)
+		if err != nil {
+			return t.unmarshalFloat(data)
+		}
+		if strings.HasPrefix(s, "-") {
+			nsec = -nsec
+		}
+	}
+
+	t.Time = time.Unix(sec, nsec)
+	return nil
+}
+
+// unmarshalFloat interprets the data as a float64 number. It is used as a
+// fallback for values in scientific notation.
+func (t *timestamp) unmarshalFloat(data []byte) error {
 	sec, err := strconv.ParseFloat(string(data), 64)
 	if err != nil {
 		return err
@@ -42,21 +75,37 @@ func (t *timestamp) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON returns t as a decimal number of seconds elapsed since
+// January 1, 1970 00:00:00 UTC, the fractional part being omitted when null.
+func (t timestamp) MarshalJSON() ([]byte, error) {
+	sign, sec, nsec := "", t.Unix(), t.Nanosecond()
+	if sec < 0 {
+		sign, sec = "-", -sec
+		if nsec != 0 {
+			sec, nsec = sec-1, 1e9-nsec
+		}
+	}
+	s := sign + strconv.FormatInt(sec, 10)
+	if nsec != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", nsec), "0")
+	}
+	return []byte(s), nil
+}
+
 type jsonRecord struct {
 	*recordAlias
-	ApproximateCreationDateTime timestamp
+	ApproximateCreationDateTime timestamp `json:"ApproximateCreationDateTime"`
 }
 
 // UnmarshalJSON interprets data as a Record with a special timestamp. It then
 // leverages type aliasing and struct embedding to fill Record with an usual
 // time.Time.
 func (r *Record) UnmarshalJSON(data []byte) error {
-	var jr jsonRecord
+	jr := jsonRecord{recordAlias: (*recordAlias)(r)}
 	if err := json.Unmarshal(data, &jr); err != nil {
 		return err
 	}
 
-	*r = *(*Record)(jr.recordAlias)
 	r.ApproximateCreationDateTime = jr.ApproximateCreationDateTime.Time
 
 	return nil
@@ -70,3 +119,35 @@ func (r *Record) MarshalJSON() ([]byte, error) {
 		timestamp{r.ApproximateCreationDateTime},
 	})
 }
+
+// MarshalJSON encodes the attribute value as a single key object named after
+// its Type. Unlike the default encoding, it preserves false booleans, empty
+// strings and empty binaries, sets, lists and maps.
+func (av *AttributeValue) MarshalJSON() ([]byte, error) {
+	t := av.Type()
+
+	var v interface{}
+	switch t {
+	case BinaryType:
+		v = av.B
+	case BooleanType:
+		v = *av.BOOL
+	case BinarySetType:
+		v = av.BS
+	case ListType:
+		v = av.L
+	case MapType:
+		v = av.M
+	case NumberType:
+		v = av.N
+	case NumberSetType:
+		v = av.NS
+	case NullType:
+		v = true
+	case StringSetType:
+		v = av.SS
+	default:
+		v = av.S
+	}
+	return json.Marshal(map[AttributeType]interface{}{t: v})
+}
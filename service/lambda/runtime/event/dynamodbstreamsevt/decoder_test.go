@@ -0,0 +1,140 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package dynamodbstreamsevt
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		data string
+		want time.Time
+		enc  string
+	}{
+		{"1428537600", time.Unix(1428537600, 0), "1428537600"},
+		{"1428537600.5", time.Unix(1428537600, 5e8), "1428537600.5"},
+		{"1428537600.123", time.Unix(1428537600, 123e6), "1428537600.123"},
+		{"1428537600.1234567891", time.Unix(1428537600, 123456789), "1428537600.123456789"},
+		{"0.001", time.Unix(0, 1e6), "0.001"},
+		{"-2", time.Unix(-2, 0), "-2"},
+		{"-1.5", time.Unix(-1, -5e8), "-1.5"},
+		{"-0.25", time.Unix(0, -25e7), "-0.25"},
+		{"1.5e3", time.Unix(1500, 0), "1500"},
+	}
+
+	for _, tt := range tests {
+		var ts timestamp
+		if err := json.Unmarshal([]byte(tt.data), &ts); err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if !ts.Equal(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.data, ts.Time, tt.want)
+		}
+
+		enc, err := json.Marshal(ts)
+		if err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if string(enc) != tt.enc {
+			t.Errorf("%s: got %s, want %s", tt.data, enc, tt.enc)
+		}
+	}
+}
+
+func TestTimestampInvalid(t *testing.T) {
+	for _, data := range []string{`"1428537600"`, `null`, `1.2.3`, `true`} {
+		var ts timestamp
+		if err := json.Unmarshal([]byte(data), &ts); err == nil {
+			t.Errorf("%s: expected error", data)
+		}
+	}
+}
+
+func TestAttributeValue(t *testing.T) {
+	tests := []struct {
+		data string
+		want AttributeType
+	}{
+		{`{"B":"AAEC"}`, BinaryType},
+		{`{"B":""}`, BinaryType},
+		{`{"BOOL":true}`, BooleanType},
+		{`{"BOOL":false}`, BooleanType},
+		{`{"BS":["AA==","AQ=="]}`, BinarySetType},
+		{`{"BS":[]}`, BinarySetType},
+		{`{"L":[{"S":"a"},{"BOOL":false}]}`, ListType},
+		{`{"L":[]}`, ListType},
+		{`{"M":{"a":{"N":"1"}}}`, MapType},
+		{`{"M":{}}`, MapType},
+		{`{"N":"-1.5e3"}`, NumberType},
+		{`{"NS":["1","2"]}`, NumberSetType},
+		{`{"NS":[]}`, NumberSetType},
+		{`{"NULL":true}`, NullType},
+		{`{"S":"a"}`, StringType},
+		{`{"S":""}`, StringType},
+		{`{"SS":["a","b"]}`, StringSetType},
+		{`{"SS":[]}`, StringSetType},
+	}
+
+	for _, tt := range tests {
+		var av AttributeValue
+		if err := json.Unmarshal([]byte(tt.data), &av); err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if got := av.Type(); got != tt.want {
+			t.Errorf("%s: got type %s, want %s", tt.data, got, tt.want)
+		}
+
+		enc, err := json.Marshal(&av)
+		if err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if string(enc) != tt.data {
+			t.Errorf("%s: got %s", tt.data, enc)
+		}
+
+		var dec AttributeValue
+		if err := json.Unmarshal(enc, &dec); err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if !reflect.DeepEqual(dec, av) {
+			t.Errorf("%s: got %#v, want %#v", tt.data, dec, av)
+		}
+	}
+}
+
+func TestAttributeValueZero(t *testing.T) {
+	var av AttributeValue
+	if got := av.Type(); got != StringType {
+		t.Errorf("got type %s, want %s", got, StringType)
+	}
+	enc, err := json.Marshal(&av)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(enc) != `{"S":""}` {
+		t.Errorf("got %s", enc)
+	}
+}
@@ -21,6 +21,23 @@ import (
 	"time"
 )
 
+// AttributeType represents the data type of an AttributeValue.
+type AttributeType string
+
+// The DynamoDB data types an AttributeValue can hold.
+const (
+	BinaryType    AttributeType = "B"
+	BooleanType   AttributeType = "BOOL"
+	BinarySetType AttributeType = "BS"
+	ListType      AttributeType = "L"
+	MapType       AttributeType = "M"
+	NumberType    AttributeType = "N"
+	NumberSetType AttributeType = "NS"
+	NullType      AttributeType = "NULL"
+	StringType    AttributeType = "S"
+	StringSetType AttributeType = "SS"
+)
+
 // AttributeValue represents the data for an attribute. One, and only one, of
 // the elements is set.
 //
@@ -37,7 +54,9 @@ type AttributeValue struct {
 	B []byte `json:",omitempty"`
 
 	// A Boolean data type.
-	BOOL bool `json:",omitempty"`
+	//
+	// BOOL is a pointer so that false can be told apart from an unset value.
+	BOOL *bool `json:",omitempty"`
 
 	// A Binary Set data type.
 	BS [][]byte `json:",omitempty"`
@@ -64,12 +83,40 @@ type AttributeValue struct {
 	SS []string `json:",omitempty"`
 }
 
+// Type returns the data type held by the attribute value.
+//
+// Binary, set, list and map types are identified by a non-nil value, even if
+// empty. An attribute value with no element set holds an empty String.
+func (av *AttributeValue) Type() AttributeType {
+	switch {
+	case av.B != nil:
+		return BinaryType
+	case av.BOOL != nil:
+		return BooleanType
+	case av.BS != nil:
+		return BinarySetType
+	case av.L != nil:
+		return ListType
+	case av.M != nil:
+		return MapType
+	case av.N != "":
+		return NumberType
+	case av.NS != nil:
+		return NumberSetType
+	case av.NULL:
+		return NullType
+	case av.SS != nil:
+		return StringSetType
+	}
+	return StringType
+}
+
 // Record is a description of a single data modification that was performed on
 // an item in a DynamoDB table.
 type Record struct {
 	// The unique identifier of the record in the stream.
 	// See also http://docs.aws.amazon.com/streams/latest/dev/kinesis-record-processor-duplicates.html
-	SequenceNumber string
+	SequenceNumber string `json:"SequenceNumber"`
 
 	// The type of data from the modified DynamoDB item that was captured in
 	// this stream record:
@@ -78,22 +125,22 @@ type Record struct {
 	// - OLD_IMAGE: the entire item, as it appeared before it was modified.
 	// - NEW_AND_OLD_IMAGES: both the new and the old item images of the
 	//   item.
-	StreamViewType string
+	StreamViewType string `json:"StreamViewType"`
 
 	// The approximate date and time when the stream record was created.
 	ApproximateCreationDateTime time.Time `json:"-"`
 
 	// The primary key attributes for the DynamoDB item that was modified.
-	Keys map[string]*AttributeValue
+	Keys map[string]*AttributeValue `json:"Keys"`
 
 	// The item attributes as before item was modified.
-	OldImage map[string]*AttributeValue
+	OldImage map[string]*AttributeValue `json:"OldImage,omitempty"`
 
 	// The item attributes after item was modified.
-	NewImage map[string]*AttributeValue
+	NewImage map[string]*AttributeValue `json:"NewImage,omitempty"`
 
 	// The size of the stream record, in bytes.
-	SizeBytes int64
+	SizeBytes int64 `json:"SizeBytes"`
 }
 
 // EventRecord provides contextual information about an Amazon DynamoDB Streams
@@ -101,14 +148,14 @@ type Record struct {
 type EventRecord struct {
 	// A globally unique identifier for the event that was recorded in this
 	// stream record.
-	EventID string
+	EventID string `json:"eventID"`
 
 	// The type of data modification that was performed on the DynamoDB
 	// table:
 	// - INSERT: a new item was added to the table.
 	// - MODIFY: one or more of an existing item's attributes were modified.
 	// - REMOVE: the item was deleted from the table.
-	EventName string
+	EventName string `json:"eventName"`
 
 	// The version number of the stream record format. This number is
 	// updated whenever the structure of Record is modified.
@@ -117,21 +164,21 @@ type EventRecord struct {
 	// a particular value, as this number is subject to change at any time.
 	// In general, EventVersion will only increase as the low-level
 	// Amazon DynamoDB Streams API evolves.
-	EventVersion string
+	EventVersion string `json:"eventVersion"`
 
 	// The AWS service from which the stream record originated.
 	// In our case the value is always "aws:dynamodb".
-	EventSource string
+	EventSource string `json:"eventSource"`
 
 	// The DynamoDB table's ARN from which the stream record originated.
-	EventSourceARN string
+	EventSourceARN string `json:"eventSourceARN"`
 
 	// The region in which the GetRecords request was received.
-	AWSRegion string
+	AWSRegion string `json:"awsRegion"`
 
 	// The main body of the stream record, containing all of the
 	// DynamoDB-specific fields.
-	DynamoDB *Record
+	DynamoDB *Record `json:"dynamodb"`
 }
 
 // String returns the string representation.
@@ -148,7 +195,7 @@ func (e *EventRecord) GoString() string {
 // Event represents an Amazon DynamoDB Streams event.
 type Event struct {
 	// The list of Amazon DynamoDB Streams event records.
-	Records []*EventRecord
+	Records []*EventRecord `json:"Records"`
 }
 
 // String returns the string representation.
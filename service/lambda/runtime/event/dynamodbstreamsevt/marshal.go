@@ -0,0 +1,357 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package dynamodbstreamsevt
+
+import (
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Marshaler is the interface implemented by types that can marshal themselves
+// into a valid AttributeValue.
+type Marshaler interface {
+	MarshalAttributeValue() (*AttributeValue, error)
+}
+
+// UnsupportedTypeError is returned by Marshal when attempting to encode an
+// unsupported value type.
+type UnsupportedTypeError struct {
+	Type reflect.Type
+}
+
+func (e *UnsupportedTypeError) Error() string {
+	return "dynamodbstreamsevt: unsupported type: " + e.Type.String()
+}
+
+var (
+	marshalerType = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	timeType      = reflect.TypeOf(time.Time{})
+	bigIntType    = reflect.TypeOf(big.Int{})
+	bigFloatType  = reflect.TypeOf(big.Float{})
+)
+
+// tagOptions represents the options of a struct field tag.
+//
+// The struct field tag is read from the "dynamodbav" key, then from the "json"
+// key when the former is missing. Supported options are:
+//   - omitempty: the field is omitted if it has an empty value.
+//   - string: the number value is encoded as a string (S) attribute.
+//   - stringset, numberset, binaryset: the slice value is encoded as a
+//     String Set (SS), Number Set (NS) or Binary Set (BS) attribute. Elements
+//     must be strings or numbers, numbers, and byte slices respectively.
+//   - unixtime: the time.Time value is encoded as a number (N) attribute
+//     holding the number of seconds elapsed since January 1, 1970 00:00:00 UTC.
+type tagOptions struct {
+	omitEmpty bool
+	asString  bool
+	stringSet bool
+	numberSet bool
+	binarySet bool
+	unixTime  bool
+}
+
+// field represents a struct field along with its attribute name and options.
+type field struct {
+	name  string
+	index []int
+	opts  tagOptions
+}
+
+// fields returns the list of fields of the struct type t to be marshalled or
+// unmarshalled. Anonymous struct fields without explicit name are flattened.
+func fields(t reflect.Type) []field {
+	var fs []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.PkgPath != "" && !sf.Anonymous {
+			continue
+		}
+
+		tag, ok := sf.Tag.Lookup("dynamodbav")
+		if !ok {
+			tag = sf.Tag.Get("json")
+		}
+		if tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		name := parts[0]
+		var opts tagOptions
+		for _, o := range parts[1:] {
+			switch o {
+			case "omitempty":
+				opts.omitEmpty = true
+			case "string":
+				opts.asString = true
+			case "stringset":
+				opts.stringSet = true
+			case "numberset":
+				opts.numberSet = true
+			case "binaryset":
+				opts.binarySet = true
+			case "unixtime":
+				opts.unixTime = true
+			}
+		}
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct && ft != timeType {
+			for _, f := range fields(ft) {
+				f.index = append([]int{i}, f.index...)
+				fs = append(fs, f)
+			}
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+		fs = append(fs, field{name, []int{i}, opts})
+	}
+	return fs
+}
+
+// MarshalMap returns the map of AttributeValue encoding of in, which must be a
+// struct or a map with string keys. It is the inverse of UnmarshalMap.
+func MarshalMap(in interface{}) (map[string]*AttributeValue, error) {
+	av, err := Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	if av.M == nil {
+		return nil, &UnsupportedTypeError{reflect.TypeOf(in)}
+	}
+	return av.M, nil
+}
+
+// Marshal returns the AttributeValue encoding of in.
+//
+// Marshal encodes strings as S, booleans as BOOL, numbers (including
+// big.Int and big.Float) as N, []byte as B, time.Time as an RFC3339 S,
+// slices and arrays as L, and structs and maps with string keys as M. Nil
+// pointers, interfaces, slices and maps are encoded as NULL.
+// See tagOptions for the struct field tag options.
+func Marshal(in interface{}) (*AttributeValue, error) {
+	return marshal(reflect.ValueOf(in), tagOptions{})
+}
+
+func marshal(v reflect.Value, opts tagOptions) (*AttributeValue, error) {
+	if !v.IsValid() {
+		return &AttributeValue{NULL: true}, nil
+	}
+
+	if v.Type().Implements(marshalerType) {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return &AttributeValue{NULL: true}, nil
+		}
+		return v.Interface().(Marshaler).MarshalAttributeValue()
+	}
+	if v.Kind() != reflect.Ptr && v.CanAddr() && v.Addr().Type().Implements(marshalerType) {
+		return v.Addr().Interface().(Marshaler).MarshalAttributeValue()
+	}
+
+	switch v.Type() {
+	case timeType:
+		t := v.Interface().(time.Time)
+		if opts.unixTime {
+			return &AttributeValue{N: strconv.FormatInt(t.Unix(), 10)}, nil
+		}
+		return &AttributeValue{S: t.Format(time.RFC3339Nano)}, nil
+	case bigIntType:
+		n := addr(v).Interface().(*big.Int)
+		return number(n.String(), opts), nil
+	case bigFloatType:
+		f := addr(v).Interface().(*big.Float)
+		return number(f.Text('g', -1), opts), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return &AttributeValue{NULL: true}, nil
+		}
+		return marshal(v.Elem(), opts)
+	case reflect.String:
+		return &AttributeValue{S: v.String()}, nil
+	case reflect.Bool:
+		b := v.Bool()
+		return &AttributeValue{BOOL: &b}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number(strconv.FormatInt(v.Int(), 10), opts), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return number(strconv.FormatUint(v.Uint(), 10), opts), nil
+	case reflect.Float32, reflect.Float64:
+		return number(strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), opts), nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return &AttributeValue{NULL: true}, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 && !opts.binarySet {
+			return &AttributeValue{B: v.Bytes()}, nil
+		}
+		return marshalList(v, opts)
+	case reflect.Array:
+		return marshalList(v, opts)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, &UnsupportedTypeError{v.Type()}
+		}
+		if v.IsNil() {
+			return &AttributeValue{NULL: true}, nil
+		}
+		m := make(map[string]*AttributeValue, v.Len())
+		for _, k := range v.MapKeys() {
+			av, err := marshal(v.MapIndex(k), tagOptions{})
+			if err != nil {
+				return nil, err
+			}
+			m[k.String()] = av
+		}
+		return &AttributeValue{M: m}, nil
+	case reflect.Struct:
+		return marshalStruct(v)
+	}
+
+	return nil, &UnsupportedTypeError{v.Type()}
+}
+
+func number(n string, opts tagOptions) *AttributeValue {
+	if opts.asString {
+		return &AttributeValue{S: n}
+	}
+	return &AttributeValue{N: n}
+}
+
+func marshalList(v reflect.Value, opts tagOptions) (*AttributeValue, error) {
+	av := &AttributeValue{}
+	switch {
+	case opts.stringSet:
+		av.SS = make([]string, 0, v.Len())
+	case opts.numberSet:
+		av.NS = make([]string, 0, v.Len())
+	case opts.binarySet:
+		av.BS = make([][]byte, 0, v.Len())
+	default:
+		av.L = make([]*AttributeValue, 0, v.Len())
+	}
+
+	for i := 0; i < v.Len(); i++ {
+		elem, err := marshal(v.Index(i), tagOptions{asString: opts.stringSet})
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case opts.stringSet:
+			if elem.Type() != StringType {
+				return nil, &UnsupportedTypeError{v.Type()}
+			}
+			av.SS = append(av.SS, elem.S)
+		case opts.numberSet:
+			if elem.N == "" {
+				return nil, &UnsupportedTypeError{v.Type()}
+			}
+			av.NS = append(av.NS, elem.N)
+		case opts.binarySet:
+			if elem.Type() != BinaryType {
+				return nil, &UnsupportedTypeError{v.Type()}
+			}
+			av.BS = append(av.BS, elem.B)
+		default:
+			av.L = append(av.L, elem)
+		}
+	}
+
+	return av, nil
+}
+
+func marshalStruct(v reflect.Value) (*AttributeValue, error) {
+	m := make(map[string]*AttributeValue)
+	for _, f := range fields(v.Type()) {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok {
+			continue
+		}
+		if f.opts.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+
+		av, err := marshal(fv, f.opts)
+		if err != nil {
+			return nil, err
+		}
+		m[f.name] = av
+	}
+	return &AttributeValue{M: m}, nil
+}
+
+// fieldByIndex returns the nested field of v corresponding to index. It
+// reports false when the field is unreachable through a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	case reflect.Struct:
+		if v.Type() == timeType {
+			return v.Interface().(time.Time).IsZero()
+		}
+	}
+	return false
+}
+
+// addr returns a pointer to v, copying v when it is not addressable.
+func addr(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v.Addr()
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p
+}
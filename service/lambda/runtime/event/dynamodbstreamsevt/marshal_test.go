@@ -0,0 +1,216 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package dynamodbstreamsevt
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type inner struct {
+	X int
+}
+
+type Inner struct {
+	Y string
+}
+
+type item struct {
+	ID       string            `dynamodbav:"id"`
+	Count    int               `json:"count"`
+	Price    float64           `dynamodbav:"price,string"`
+	Tags     []string          `dynamodbav:"tags,stringset"`
+	Codes    []int             `dynamodbav:"codes,stringset"`
+	Scores   []float64         `dynamodbav:"scores,numberset"`
+	Blobs    [][]byte          `dynamodbav:"blobs,binaryset"`
+	Created  time.Time         `dynamodbav:"created,unixtime"`
+	Updated  time.Time         `dynamodbav:"updated"`
+	Note     string            `dynamodbav:"note,omitempty"`
+	Parent   *item             `dynamodbav:"parent,omitempty"`
+	Data     []byte            `dynamodbav:"data"`
+	Attrs    map[string]string `dynamodbav:"attrs"`
+	List     []interface{}     `dynamodbav:"list"`
+	Ignored  string            `dynamodbav:"-"`
+	internal string
+	Inner
+}
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+		want *AttributeValue
+	}{
+		{"string", "a", &AttributeValue{S: "a"}},
+		{"empty string", "", &AttributeValue{S: ""}},
+		{"bool", true, &AttributeValue{BOOL: &yes}},
+		{"int", -42, &AttributeValue{N: "-42"}},
+		{"uint", uint64(18446744073709551615), &AttributeValue{N: "18446744073709551615"}},
+		{"float", 3.25, &AttributeValue{N: "3.25"}},
+		{"float32", float32(0.1), &AttributeValue{N: "0.1"}},
+		{"big int", big.NewInt(1).Lsh(big.NewInt(1), 100), &AttributeValue{N: "1267650600228229401496703205376"}},
+		{"big float", *big.NewFloat(1.5), &AttributeValue{N: "1.5"}},
+		{"bytes", []byte{0, 1, 2}, &AttributeValue{B: []byte{0, 1, 2}}},
+		{"nil pointer", (*int)(nil), &AttributeValue{NULL: true}},
+		{"nil slice", []string(nil), &AttributeValue{NULL: true}},
+		{"nil map", map[string]int(nil), &AttributeValue{NULL: true}},
+		{"nil", nil, &AttributeValue{NULL: true}},
+		{"time", time.Date(2017, 3, 1, 12, 0, 0, 5, time.UTC), &AttributeValue{S: "2017-03-01T12:00:00.000000005Z"}},
+		{"array", [2]int{1, 2}, &AttributeValue{L: []*AttributeValue{{N: "1"}, {N: "2"}}}},
+		{
+			"nested list and map",
+			[]interface{}{"a", map[string]interface{}{"b": []int{1}}},
+			&AttributeValue{L: []*AttributeValue{
+				{S: "a"},
+				{M: map[string]*AttributeValue{"b": {L: []*AttributeValue{{N: "1"}}}}},
+			}},
+		},
+		{
+			"struct",
+			item{
+				ID:      "i",
+				Count:   3,
+				Price:   9.99,
+				Tags:    []string{"x", "y"},
+				Codes:   []int{7},
+				Scores:  []float64{1, 2.5},
+				Blobs:   [][]byte{{1}},
+				Created: time.Unix(1479499740, 0),
+				Updated: time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC),
+				Parent:  &item{ID: "p"},
+				Data:    []byte("d"),
+				Attrs:   map[string]string{"k": "v"},
+				List:    []interface{}{true, nil},
+				Ignored: "ignored",
+				Inner:   Inner{Y: "y"},
+			},
+			&AttributeValue{M: map[string]*AttributeValue{
+				"id":      {S: "i"},
+				"count":   {N: "3"},
+				"price":   {S: "9.99"},
+				"tags":    {SS: []string{"x", "y"}},
+				"codes":   {SS: []string{"7"}},
+				"scores":  {NS: []string{"1", "2.5"}},
+				"blobs":   {BS: [][]byte{{1}}},
+				"created": {N: "1479499740"},
+				"updated": {S: "2017-03-01T12:00:00Z"},
+				"parent": {M: map[string]*AttributeValue{
+					"id":      {S: "p"},
+					"count":   {N: "0"},
+					"price":   {S: "0"},
+					"tags":    {NULL: true},
+					"codes":   {NULL: true},
+					"scores":  {NULL: true},
+					"blobs":   {NULL: true},
+					"created": {N: "-62135596800"},
+					"updated": {S: "0001-01-01T00:00:00Z"},
+					"data":    {NULL: true},
+					"attrs":   {NULL: true},
+					"list":    {NULL: true},
+					"Y":       {S: ""},
+				}},
+				"data":  {B: []byte("d")},
+				"attrs": {M: map[string]*AttributeValue{"k": {S: "v"}}},
+				"list":  {L: []*AttributeValue{{BOOL: &yes}, {NULL: true}}},
+				"Y":     {S: "y"},
+			}},
+		},
+		{
+			"nil unexported embedded pointer",
+			struct {
+				*inner
+				Name string
+			}{Name: "n"},
+			&AttributeValue{M: map[string]*AttributeValue{"Name": {S: "n"}}},
+		},
+		{
+			"unexported embedded pointer",
+			struct {
+				*inner
+				Name string
+			}{&inner{3}, "n"},
+			&AttributeValue{M: map[string]*AttributeValue{"X": {N: "3"}, "Name": {S: "n"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarshalUnsupported(t *testing.T) {
+	tests := []struct {
+		name string
+		in   interface{}
+	}{
+		{"channel", make(chan int)},
+		{"int keyed map", map[int]string{1: "a"}},
+		{"bool in string set", struct {
+			V []bool `dynamodbav:",stringset"`
+		}{[]bool{false}}},
+		{"nil in string set", struct {
+			V []*string `dynamodbav:",stringset"`
+		}{[]*string{nil}}},
+		{"string in number set", struct {
+			V []string `dynamodbav:",numberset"`
+		}{[]string{"1"}}},
+		{"string in binary set", struct {
+			V []string `dynamodbav:",binaryset"`
+		}{[]string{"a"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Marshal(tt.in)
+			if _, ok := err.(*UnsupportedTypeError); !ok {
+				t.Errorf("got %v, want *UnsupportedTypeError", err)
+			}
+		})
+	}
+}
+
+type upper string
+
+func (u upper) MarshalAttributeValue() (*AttributeValue, error) {
+	return &AttributeValue{S: "U:" + string(u)}, nil
+}
+
+func TestMarshalMap(t *testing.T) {
+	m, err := MarshalMap(struct {
+		Name upper `json:"name"`
+	}{"a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]*AttributeValue{"name": {S: "U:a"}}; !reflect.DeepEqual(m, want) {
+		t.Errorf("got %v, want %v", m, want)
+	}
+
+	if _, err := MarshalMap("a"); err == nil {
+		t.Error("expected error")
+	}
+}
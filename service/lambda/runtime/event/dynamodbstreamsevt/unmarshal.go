@@ -0,0 +1,379 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package dynamodbstreamsevt
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Unmarshaler is the interface implemented by types that can unmarshal an
+// AttributeValue description of themselves.
+type Unmarshaler interface {
+	UnmarshalAttributeValue(*AttributeValue) error
+}
+
+// UnmarshalTypeError describes an AttributeValue that was not appropriate for
+// a value of a specific Go type.
+type UnmarshalTypeError struct {
+	// The description of the AttributeValue, for instance "N 3.14".
+	Value string
+
+	// The type of Go value it could not be assigned to.
+	Type reflect.Type
+}
+
+func (e *UnmarshalTypeError) Error() string {
+	return "dynamodbstreamsevt: cannot unmarshal " + e.Value + " into Go value of type " + e.Type.String()
+}
+
+// UnmarshalEmbeddedError is returned by Unmarshal when a struct field promoted
+// through a nil pointer to an unexported embedded struct must be set, as such
+// a pointer cannot be allocated.
+type UnmarshalEmbeddedError struct {
+	Type reflect.Type
+}
+
+func (e *UnmarshalEmbeddedError) Error() string {
+	return "dynamodbstreamsevt: cannot set embedded pointer to unexported struct type " + e.Type.String()
+}
+
+var (
+	unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+
+	errInvalidUnmarshal = errors.New("dynamodbstreamsevt: Unmarshal(non-pointer or nil)")
+)
+
+// UnmarshalMap decodes the map of AttributeValue m, typically Record Keys,
+// NewImage or OldImage, into the value pointed to by out, which is usually a
+// pointer to a struct. See Unmarshal for details.
+func UnmarshalMap(m map[string]*AttributeValue, out interface{}) error {
+	return Unmarshal(&AttributeValue{M: m}, out)
+}
+
+// Unmarshal decodes av into the value pointed to by out.
+//
+// Unmarshal is the inverse of Marshal. Besides, N can be decoded into any
+// integer, floating point, big.Int or big.Float value and time.Time is
+// decoded from either an RFC3339 S or an N holding the number of seconds
+// elapsed since January 1, 1970 00:00:00 UTC. Sets (SS, NS and BS) are decoded
+// into slices. NULL sets the value to its zero value.
+//
+// When decoding into an empty interface, Unmarshal stores S as string, N as
+// float64, B as []byte, BOOL as bool, L as []interface{}, M as
+// map[string]interface{}, SS as []string, NS as []float64, BS as [][]byte and
+// NULL as nil.
+func Unmarshal(av *AttributeValue, out interface{}) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return errInvalidUnmarshal
+	}
+	return unmarshal(av, v.Elem())
+}
+
+func unmarshal(av *AttributeValue, v reflect.Value) error {
+	if av == nil || av.Type() == NullType {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		if v.Type().Implements(unmarshalerType) {
+			return v.Interface().(Unmarshaler).UnmarshalAttributeValue(av)
+		}
+		return unmarshal(av, v.Elem())
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(unmarshalerType) {
+		return v.Addr().Interface().(Unmarshaler).UnmarshalAttributeValue(av)
+	}
+
+	switch v.Type() {
+	case timeType:
+		return unmarshalTime(av, v)
+	case bigIntType:
+		n, ok := new(big.Int).SetString(av.N, 10)
+		if av.N == "" || !ok {
+			return typeError(av, v)
+		}
+		v.Set(reflect.ValueOf(n).Elem())
+		return nil
+	case bigFloatType:
+		f, ok := new(big.Float).SetString(av.N)
+		if av.N == "" || !ok {
+			return typeError(av, v)
+		}
+		v.Set(reflect.ValueOf(f).Elem())
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return typeError(av, v)
+		}
+		i, err := unmarshalInterface(av)
+		if err != nil {
+			return err
+		}
+		if i == nil {
+			v.Set(reflect.Zero(v.Type()))
+		} else {
+			v.Set(reflect.ValueOf(i))
+		}
+		return nil
+	case reflect.String:
+		switch av.Type() {
+		case NumberType:
+			v.SetString(av.N)
+		case StringType:
+			v.SetString(av.S)
+		default:
+			return typeError(av, v)
+		}
+		return nil
+	case reflect.Bool:
+		if av.Type() != BooleanType {
+			return typeError(av, v)
+		}
+		v.SetBool(*av.BOOL)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(numberOf(av), 10, v.Type().Bits())
+		if err != nil {
+			return typeError(av, v)
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(numberOf(av), 10, v.Type().Bits())
+		if err != nil {
+			return typeError(av, v)
+		}
+		v.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(numberOf(av), v.Type().Bits())
+		if err != nil {
+			return typeError(av, v)
+		}
+		v.SetFloat(f)
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 && av.B != nil {
+			v.SetBytes(append([]byte(nil), av.B...))
+			return nil
+		}
+		n := listLen(av)
+		if n < 0 {
+			return typeError(av, v)
+		}
+		s := reflect.MakeSlice(v.Type(), n, n)
+		if err := unmarshalList(av, s); err != nil {
+			return err
+		}
+		v.Set(s)
+		return nil
+	case reflect.Array:
+		n := listLen(av)
+		if n < 0 || n > v.Len() {
+			return typeError(av, v)
+		}
+		return unmarshalList(av, v)
+	case reflect.Map:
+		if av.M == nil || v.Type().Key().Kind() != reflect.String {
+			return typeError(av, v)
+		}
+		m := reflect.MakeMap(v.Type())
+		for k, elem := range av.M {
+			ev := reflect.New(v.Type().Elem()).Elem()
+			if err := unmarshal(elem, ev); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(k).Convert(v.Type().Key()), ev)
+		}
+		v.Set(m)
+		return nil
+	case reflect.Struct:
+		if av.M == nil {
+			return typeError(av, v)
+		}
+		for _, f := range fields(v.Type()) {
+			elem, ok := lookup(av.M, f.name)
+			if !ok {
+				continue
+			}
+			fv := v
+			for i, x := range f.index {
+				if i > 0 && fv.Kind() == reflect.Ptr {
+					if fv.IsNil() {
+						if !fv.CanSet() {
+							return &UnmarshalEmbeddedError{fv.Type().Elem()}
+						}
+						fv.Set(reflect.New(fv.Type().Elem()))
+					}
+					fv = fv.Elem()
+				}
+				fv = fv.Field(x)
+			}
+			if err := unmarshal(elem, fv); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return typeError(av, v)
+}
+
+func unmarshalTime(av *AttributeValue, v reflect.Value) error {
+	if av.Type() == NumberType {
+		sec, err := strconv.ParseInt(av.N, 10, 64)
+		if err != nil {
+			return typeError(av, v)
+		}
+		v.Set(reflect.ValueOf(time.Unix(sec, 0)))
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, av.S)
+	if err != nil {
+		return typeError(av, v)
+	}
+	v.Set(reflect.ValueOf(t))
+	return nil
+}
+
+// unmarshalList decodes the list or set av into the slice or array v, which
+// must be large enough to hold every element.
+func unmarshalList(av *AttributeValue, v reflect.Value) error {
+	for i := 0; i < listLen(av); i++ {
+		var elem *AttributeValue
+		switch {
+		case av.L != nil:
+			elem = av.L[i]
+		case av.SS != nil:
+			elem = &AttributeValue{S: av.SS[i]}
+		case av.NS != nil:
+			elem = &AttributeValue{N: av.NS[i]}
+		case av.BS != nil:
+			elem = &AttributeValue{B: av.BS[i]}
+		}
+		if err := unmarshal(elem, v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func unmarshalInterface(av *AttributeValue) (interface{}, error) {
+	switch av.Type() {
+	case NullType:
+		return nil, nil
+	case BinaryType:
+		return append([]byte(nil), av.B...), nil
+	case BooleanType:
+		return *av.BOOL, nil
+	case NumberType:
+		return strconv.ParseFloat(av.N, 64)
+	case ListType:
+		l := make([]interface{}, len(av.L))
+		for i, elem := range av.L {
+			if err := unmarshal(elem, reflect.ValueOf(&l[i]).Elem()); err != nil {
+				return nil, err
+			}
+		}
+		return l, nil
+	case MapType:
+		m := make(map[string]interface{}, len(av.M))
+		if err := unmarshal(av, reflect.ValueOf(&m).Elem()); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case StringSetType:
+		return append([]string(nil), av.SS...), nil
+	case NumberSetType:
+		ns := make([]float64, len(av.NS))
+		if err := unmarshal(av, reflect.ValueOf(&ns).Elem()); err != nil {
+			return nil, err
+		}
+		return ns, nil
+	case BinarySetType:
+		bs := make([][]byte, len(av.BS))
+		if err := unmarshal(av, reflect.ValueOf(&bs).Elem()); err != nil {
+			return nil, err
+		}
+		return bs, nil
+	}
+	return av.S, nil
+}
+
+// lookup returns the element of m keyed by name, preferring an exact match
+// but accepting a case-insensitive match as encoding/json does.
+func lookup(m map[string]*AttributeValue, name string) (*AttributeValue, bool) {
+	if av, ok := m[name]; ok {
+		return av, true
+	}
+	for k, av := range m {
+		if strings.EqualFold(k, name) {
+			return av, true
+		}
+	}
+	return nil, false
+}
+
+// listLen returns the number of elements of the list or set av, or -1 if av
+// is neither a list nor a set.
+func listLen(av *AttributeValue) int {
+	switch {
+	case av.L != nil:
+		return len(av.L)
+	case av.SS != nil:
+		return len(av.SS)
+	case av.NS != nil:
+		return len(av.NS)
+	case av.BS != nil:
+		return len(av.BS)
+	}
+	return -1
+}
+
+// numberOf returns the number held by av, either as N or as a string encoded
+// number.
+func numberOf(av *AttributeValue) string {
+	if av.N != "" {
+		return av.N
+	}
+	return av.S
+}
+
+func typeError(av *AttributeValue, v reflect.Value) error {
+	desc := string(av.Type())
+	switch av.Type() {
+	case NumberType:
+		desc += " " + av.N
+	case StringType:
+		desc += " " + strconv.Quote(av.S)
+	}
+	return &UnmarshalTypeError{desc, v.Type()}
+}
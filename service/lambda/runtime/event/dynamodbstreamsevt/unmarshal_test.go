@@ -0,0 +1,237 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package dynamodbstreamsevt
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var yes = true
+
+func TestUnmarshal(t *testing.T) {
+	one := 1
+	tests := []struct {
+		name string
+		av   *AttributeValue
+		out  interface{}
+		want interface{}
+	}{
+		{"N into int", &AttributeValue{N: "-42"}, new(int), -42},
+		{"N into int8", &AttributeValue{N: "127"}, new(int8), int8(127)},
+		{"N into uint", &AttributeValue{N: "42"}, new(uint), uint(42)},
+		{"N into uint64", &AttributeValue{N: "18446744073709551615"}, new(uint64), uint64(18446744073709551615)},
+		{"N into float64", &AttributeValue{N: "3.25"}, new(float64), 3.25},
+		{"N into float32", &AttributeValue{N: "1e3"}, new(float32), float32(1000)},
+		{"N into big.Int", &AttributeValue{N: "1267650600228229401496703205376"}, new(big.Int), *new(big.Int).Lsh(big.NewInt(1), 100)},
+		{"N into big.Float", &AttributeValue{N: "1.5"}, new(big.Float), *bigFloat("1.5")},
+		{"N into string", &AttributeValue{N: "12"}, new(string), "12"},
+		{"S into int", &AttributeValue{S: "12"}, new(int), 12},
+		{"S into string", &AttributeValue{S: "a"}, new(string), "a"},
+		{"empty S into string", &AttributeValue{}, new(string), ""},
+		{"BOOL into bool", &AttributeValue{BOOL: &yes}, new(bool), true},
+		{"B into bytes", &AttributeValue{B: []byte{0, 1}}, new([]byte), []byte{0, 1}},
+		{"N into time", &AttributeValue{N: "1479499740"}, new(time.Time), time.Unix(1479499740, 0)},
+		{"S into time", &AttributeValue{S: "2017-03-01T12:00:00Z"}, new(time.Time), time.Date(2017, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{"SS into slice", &AttributeValue{SS: []string{"a", "b"}}, new([]string), []string{"a", "b"}},
+		{"NS into slice", &AttributeValue{NS: []string{"1", "2.5"}}, new([]float64), []float64{1, 2.5}},
+		{"BS into slice", &AttributeValue{BS: [][]byte{{1}, {2}}}, new([][]byte), [][]byte{{1}, {2}}},
+		{"L into array", &AttributeValue{L: []*AttributeValue{{N: "1"}}}, new([2]int), [2]int{1, 0}},
+		{"NULL into pointer", &AttributeValue{NULL: true}, &[]*int{&one}[0], (*int)(nil)},
+		{"NULL into slice", &AttributeValue{NULL: true}, &[][]int{{1}}[0], []int(nil)},
+		{"N into pointer", &AttributeValue{N: "1"}, new(*int), &one},
+		{
+			"nested M and L",
+			&AttributeValue{M: map[string]*AttributeValue{
+				"a": {L: []*AttributeValue{{M: map[string]*AttributeValue{"b": {N: "1"}}}}},
+			}},
+			new(map[string][]map[string]int),
+			map[string][]map[string]int{"a": {{"b": 1}}},
+		},
+		{
+			"interface",
+			&AttributeValue{L: []*AttributeValue{
+				{S: "a"}, {N: "1"}, {BOOL: &yes}, {NULL: true}, {B: []byte{1}},
+				{SS: []string{"s"}}, {NS: []string{"2"}}, {BS: [][]byte{{3}}},
+				{M: map[string]*AttributeValue{"k": {S: "v"}}},
+			}},
+			new(interface{}),
+			[]interface{}{
+				"a", 1.0, true, nil, []byte{1},
+				[]string{"s"}, []float64{2}, [][]byte{{3}},
+				map[string]interface{}{"k": "v"},
+			},
+		},
+		{
+			"struct",
+			&AttributeValue{M: map[string]*AttributeValue{
+				"id":      {S: "i"},
+				"COUNT":   {N: "3"},
+				"price":   {S: "9.99"},
+				"tags":    {SS: []string{"x"}},
+				"codes":   {SS: []string{"7"}},
+				"scores":  {NS: []string{"2.5"}},
+				"blobs":   {BS: [][]byte{{1}}},
+				"created": {N: "1479499740"},
+				"parent":  {M: map[string]*AttributeValue{"id": {S: "p"}}},
+				"data":    {B: []byte("d")},
+				"attrs":   {M: map[string]*AttributeValue{"k": {S: "v"}}},
+				"list":    {L: []*AttributeValue{{BOOL: &yes}}},
+				"Ignored": {S: "ignored"},
+				"Y":       {S: "y"},
+			}},
+			new(item),
+			item{
+				ID:      "i",
+				Count:   3,
+				Price:   9.99,
+				Tags:    []string{"x"},
+				Codes:   []int{7},
+				Scores:  []float64{2.5},
+				Blobs:   [][]byte{{1}},
+				Created: time.Unix(1479499740, 0),
+				Parent:  &item{ID: "p"},
+				Data:    []byte("d"),
+				Attrs:   map[string]string{"k": "v"},
+				List:    []interface{}{true},
+				Inner:   Inner{Y: "y"},
+			},
+		},
+		{
+			"allocated unexported embedded pointer",
+			&AttributeValue{M: map[string]*AttributeValue{"X": {N: "3"}}},
+			&struct {
+				*inner
+				Name string
+			}{inner: new(inner)},
+			struct {
+				*inner
+				Name string
+			}{&inner{3}, ""},
+		},
+		{
+			"nil unexported embedded pointer without its fields",
+			&AttributeValue{M: map[string]*AttributeValue{"Name": {S: "n"}}},
+			new(struct {
+				*inner
+				Name string
+			}),
+			struct {
+				*inner
+				Name string
+			}{Name: "n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Unmarshal(tt.av, tt.out); err != nil {
+				t.Fatal(err)
+			}
+			got := reflect.ValueOf(tt.out).Elem().Interface()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func bigFloat(s string) *big.Float {
+	f, _ := new(big.Float).SetString(s)
+	return f
+}
+
+func TestUnmarshalTypeError(t *testing.T) {
+	tests := []struct {
+		name string
+		av   *AttributeValue
+		out  interface{}
+		want string
+	}{
+		{"S into int", &AttributeValue{S: "a"}, new(int), `dynamodbstreamsevt: cannot unmarshal S "a" into Go value of type int`},
+		{"N overflows int8", &AttributeValue{N: "128"}, new(int8), "dynamodbstreamsevt: cannot unmarshal N 128 into Go value of type int8"},
+		{"negative N into uint", &AttributeValue{N: "-1"}, new(uint), "dynamodbstreamsevt: cannot unmarshal N -1 into Go value of type uint"},
+		{"fractional N into big.Int", &AttributeValue{N: "1.5"}, new(big.Int), "dynamodbstreamsevt: cannot unmarshal N 1.5 into Go value of type big.Int"},
+		{"N into bool", &AttributeValue{N: "1"}, new(bool), "dynamodbstreamsevt: cannot unmarshal N 1 into Go value of type bool"},
+		{"M into slice", &AttributeValue{M: map[string]*AttributeValue{}}, new([]int), "dynamodbstreamsevt: cannot unmarshal M into Go value of type []int"},
+		{"L into struct", &AttributeValue{L: []*AttributeValue{}}, new(item), "dynamodbstreamsevt: cannot unmarshal L into Go value of type dynamodbstreamsevt.item"},
+		{"L too long for array", &AttributeValue{L: []*AttributeValue{{N: "1"}, {N: "2"}}}, new([1]int), "dynamodbstreamsevt: cannot unmarshal L into Go value of type [1]int"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Unmarshal(tt.av, tt.out)
+			if _, ok := err.(*UnmarshalTypeError); !ok || err.Error() != tt.want {
+				t.Errorf("got %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshalEmbeddedError(t *testing.T) {
+	var out struct {
+		*inner
+		Name string
+	}
+	err := UnmarshalMap(map[string]*AttributeValue{"X": {N: "3"}}, &out)
+	if _, ok := err.(*UnmarshalEmbeddedError); !ok {
+		t.Errorf("got %v, want *UnmarshalEmbeddedError", err)
+	}
+}
+
+func TestUnmarshalInvalid(t *testing.T) {
+	var i int
+	for _, out := range []interface{}{nil, i, (*int)(nil)} {
+		if err := Unmarshal(&AttributeValue{N: "1"}, out); err != errInvalidUnmarshal {
+			t.Errorf("%#v: got %v", out, err)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	in := item{
+		ID:      "i",
+		Count:   -3,
+		Price:   9.99,
+		Tags:    []string{"x", "y"},
+		Codes:   []int{7, 8},
+		Scores:  []float64{1, 2.5},
+		Blobs:   [][]byte{{1}, {2}},
+		Created: time.Unix(1479499740, 0),
+		Updated: time.Date(2017, 3, 1, 12, 0, 0, 5, time.UTC),
+		Note:    "n",
+		Parent:  &item{ID: "p", Created: time.Unix(0, 0), Updated: time.Unix(0, 0).UTC()},
+		Data:    []byte("d"),
+		Attrs:   map[string]string{"k": "v"},
+		List:    []interface{}{"a", 1.5, false, nil, map[string]interface{}{"k": []interface{}{"v"}}},
+		Inner:   Inner{Y: "y"},
+	}
+
+	m, err := MarshalMap(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out item
+	if err := UnmarshalMap(m, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(out, in) {
+		t.Errorf("got %#v, want %#v", out, in)
+	}
+}
@@ -0,0 +1,74 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package eventbridgeevt
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event represents an Amazon EventBridge event.
+// See also http://docs.aws.amazon.com/eventbridge/latest/userguide/eb-events-structure.html
+type Event struct {
+	// The event structure version. Currently always "0".
+	Version string `json:"version"`
+
+	// A unique value generated for every event.
+	ID string `json:"id"`
+
+	// Identifies, in combination with the source field, the fields and
+	// values that will appear in the detail field.
+	DetailType string `json:"detail-type"`
+
+	// Identifies the service that sourced the event. All events sourced
+	// from within AWS will begin with "aws.". Customer-generated events can
+	// have any value here as long as it doesn't begin with "aws.".
+	Source string `json:"source"`
+
+	// The 12-digit number identifying an AWS account.
+	Account string `json:"account"`
+
+	// The event timestamp, which can be specified by the service
+	// originating the event.
+	Time time.Time `json:"time"`
+
+	// Identifies the AWS region where the event originated.
+	Region string `json:"region"`
+
+	// This JSON array contains ARNs that identify resources that are
+	// involved in the event.
+	Resources []string `json:"resources"`
+
+	// The name of the replay the event is part of. Provided for replayed
+	// events only, otherwise empty.
+	ReplayName string `json:"replay-name,omitempty"`
+
+	// A JSON object, whose content is at the discretion of the service
+	// originating the event. See DecodeDetail.
+	Detail json.RawMessage `json:"detail"`
+}
+
+// String returns the string representation.
+func (e *Event) String() string {
+	s, _ := json.Marshal(e)
+	return string(s)
+}
+
+// GoString returns the string representation.
+func (e *Event) GoString() string {
+	return e.String()
+}
@@ -0,0 +1,209 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package eventbridgeevt
+
+import (
+	"encoding/json"
+	"time"
+)
+
+func init() {
+	RegisterDetail("aws.ec2", "EC2 Instance State-change Notification", EC2InstanceStateChange{})
+	RegisterDetail("aws.ecs", "ECS Task State Change", ECSTaskStateChange{})
+	RegisterDetail("aws.codebuild", "CodeBuild Build State Change", CodeBuildStateChange{})
+	RegisterDetail("aws.codebuild", "CodeBuild Build Phase Change", CodeBuildStateChange{})
+	for _, dt := range []string{
+		"Object Created",
+		"Object Deleted",
+		"Object Restore Initiated",
+		"Object Restore Completed",
+		"Object Restore Expired",
+		"Object Tags Added",
+		"Object Tags Deleted",
+		"Object ACL Updated",
+		"Object Storage Class Changed",
+		"Object Access Tier Changed",
+	} {
+		RegisterDetail("aws.s3", dt, S3ObjectEvent{})
+	}
+}
+
+// EC2InstanceStateChange represents the detail of an Amazon EC2 instance state
+// change notification.
+type EC2InstanceStateChange struct {
+	// The ID of the instance.
+	InstanceID string `json:"instance-id"`
+
+	// The new state of the instance: pending, running, stopping, stopped,
+	// shutting-down or terminated.
+	State string `json:"state"`
+}
+
+// ECSContainer provides information about a container of an Amazon ECS task.
+type ECSContainer struct {
+	// The ARN of the container.
+	ContainerARN string `json:"containerArn"`
+
+	// The name of the container.
+	Name string `json:"name"`
+
+	// The last known status of the container.
+	LastStatus string `json:"lastStatus"`
+
+	// The exit code of the container, once stopped, otherwise nil.
+	ExitCode *int `json:"exitCode,omitempty"`
+
+	// A short description of the reason the container stopped, if any.
+	Reason string `json:"reason,omitempty"`
+
+	// The ARN of the task the container belongs to.
+	TaskARN string `json:"taskArn"`
+}
+
+// ECSTaskStateChange represents the detail of an Amazon ECS task state change
+// event.
+type ECSTaskStateChange struct {
+	// The ARN of the cluster hosting the task.
+	ClusterARN string `json:"clusterArn"`
+
+	// The ARN of the task.
+	TaskARN string `json:"taskArn"`
+
+	// The ARN of the task definition of the task.
+	TaskDefinitionARN string `json:"taskDefinitionArn"`
+
+	// The name of the task group, such as "service:my-service".
+	Group string `json:"group,omitempty"`
+
+	// The launch type of the task: EC2 or FARGATE.
+	LaunchType string `json:"launchType,omitempty"`
+
+	// The last known status of the task.
+	LastStatus string `json:"lastStatus"`
+
+	// The desired status of the task.
+	DesiredStatus string `json:"desiredStatus"`
+
+	// The containers of the task.
+	Containers []*ECSContainer `json:"containers"`
+
+	// The time the task was created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// The time the task started, if started.
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+
+	// The time the task stopped, if stopped.
+	StoppedAt *time.Time `json:"stoppedAt,omitempty"`
+
+	// The reason the task stopped, if stopped.
+	StoppedReason string `json:"stoppedReason,omitempty"`
+
+	// The stop code of the task, if stopped.
+	StopCode string `json:"stopCode,omitempty"`
+
+	// The version of the task, incremented on each change.
+	Version int `json:"version"`
+}
+
+// CodeBuildStateChange represents the detail of an AWS CodeBuild build state
+// or build phase change event.
+type CodeBuildStateChange struct {
+	// The status of the build: IN_PROGRESS, SUCCEEDED, FAILED or STOPPED.
+	BuildStatus string `json:"build-status"`
+
+	// The name of the build project.
+	ProjectName string `json:"project-name"`
+
+	// The ARN of the build.
+	BuildID string `json:"build-id"`
+
+	// The current phase of the build.
+	CurrentPhase string `json:"current-phase"`
+
+	// The context of the current phase of the build.
+	CurrentPhaseContext string `json:"current-phase-context"`
+
+	// The version of the event structure.
+	Version string `json:"version"`
+
+	// The completed phase, for build phase change events, otherwise empty.
+	CompletedPhase string `json:"completed-phase,omitempty"`
+
+	// The status of the completed phase, for build phase change events,
+	// otherwise empty.
+	CompletedPhaseStatus string `json:"completed-phase-status,omitempty"`
+
+	// The additional information about the build, such as its environment,
+	// logs and phases.
+	AdditionalInformation json.RawMessage `json:"additional-information,omitempty"`
+}
+
+// S3Bucket provides information about the Amazon S3 bucket of an event.
+type S3Bucket struct {
+	// The bucket name.
+	Name string `json:"name"`
+}
+
+// S3Object provides information about the Amazon S3 object of an event.
+type S3Object struct {
+	// The object key. Unlike Amazon S3 notifications, it is not URL
+	// encoded.
+	Key string `json:"key"`
+
+	// The object size in bytes.
+	Size int64 `json:"size,omitempty"`
+
+	// The object ETag.
+	ETag string `json:"etag,omitempty"`
+
+	// The object version if bucket is versioning-enabled, otherwise empty.
+	VersionID string `json:"version-id,omitempty"`
+
+	// A string representation of a hexadecimal value used to determine
+	// event sequence.
+	Sequencer string `json:"sequencer,omitempty"`
+}
+
+// S3ObjectEvent represents the detail of an Amazon S3 object event, sent when
+// Amazon EventBridge notifications are enabled on the bucket.
+type S3ObjectEvent struct {
+	// The version of the event structure.
+	Version string `json:"version"`
+
+	// The bucket of the object.
+	Bucket S3Bucket `json:"bucket"`
+
+	// The object.
+	Object S3Object `json:"object"`
+
+	// The ID of the request which caused the event.
+	RequestID string `json:"request-id"`
+
+	// The AWS account ID or AWS service principal of the requester.
+	Requester string `json:"requester"`
+
+	// The IP address of the requester, if any.
+	SourceIPAddress string `json:"source-ip-address,omitempty"`
+
+	// The operation which caused the event, such as PutObject.
+	Reason string `json:"reason,omitempty"`
+
+	// The type of deletion, for Object Deleted events: "Permanently
+	// Deleted" or "Delete Marker Created".
+	DeletionType string `json:"deletion-type,omitempty"`
+}
@@ -0,0 +1,26 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+/*
+Package eventbridgeevt allows you to write AWS Lambda functions to process
+Amazon EventBridge events, formerly Amazon CloudWatch Events, whether sent by
+AWS services or by custom applications.
+
+The detail of an event is decoded to the Go type registered for its source and
+detail type. The types of common AWS services events are registered by
+default, and RegisterDetail registers the ones of custom events.
+*/
+package eventbridgeevt
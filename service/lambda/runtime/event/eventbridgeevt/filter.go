@@ -0,0 +1,340 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package eventbridgeevt
+
+import (
+	"encoding/json"
+	"net"
+	"strings"
+)
+
+// filter matches a single value of an event. Arrays are handled by the caller,
+// which applies the filter to each of their elements.
+type filter interface {
+	match(v interface{}, present bool) bool
+}
+
+func compileFilter(f interface{}) (filter, error) {
+	obj, ok := f.(map[string]interface{})
+	if !ok {
+		return compileExact(f)
+	}
+	if len(obj) != 1 {
+		return nil, patternError("filter must have a single operator")
+	}
+
+	// Read the single operator of the filter.
+	var op string
+	var arg interface{}
+	for op, arg = range obj {
+	}
+
+	switch op {
+	case "prefix", "suffix":
+		s, fold, err := stringArg(op, arg)
+		if err != nil {
+			return nil, err
+		}
+		return affixFilter{s, op == "suffix", fold}, nil
+	case "equals-ignore-case":
+		s, ok := arg.(string)
+		if !ok {
+			return nil, patternError("equals-ignore-case needs a string")
+		}
+		return foldFilter(s), nil
+	case "wildcard":
+		s, ok := arg.(string)
+		if !ok {
+			return nil, patternError("wildcard needs a string")
+		}
+		return wildcardFilter(s), nil
+	case "anything-but":
+		return compileAnythingBut(arg)
+	case "numeric":
+		return compileNumeric(arg)
+	case "exists":
+		b, ok := arg.(bool)
+		if !ok {
+			return nil, patternError("exists needs a boolean")
+		}
+		return existsFilter(b), nil
+	case "cidr":
+		s, ok := arg.(string)
+		if !ok {
+			return nil, patternError("cidr needs a string")
+		}
+		_, ipnet, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, patternError("invalid cidr: %s", s)
+		}
+		return cidrFilter{ipnet}, nil
+	default:
+		return nil, patternError("unsupported operator: %s", op)
+	}
+}
+
+// stringArg reads the argument of the prefix and suffix operators, either a
+// string or an {"equals-ignore-case": string} object.
+func stringArg(op string, arg interface{}) (s string, fold bool, err error) {
+	switch v := arg.(type) {
+	case string:
+		return v, false, nil
+	case map[string]interface{}:
+		if s, ok := v["equals-ignore-case"].(string); ok && len(v) == 1 {
+			return s, true, nil
+		}
+	}
+	return "", false, patternError("%s needs a string", op)
+}
+
+// exactFilter matches a string, a number, a boolean or null.
+type exactFilter struct {
+	v interface{}
+}
+
+func compileExact(v interface{}) (filter, error) {
+	switch v := v.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return nil, patternError("invalid number: %s", v)
+		}
+		return exactFilter{f}, nil
+	case string, bool, nil:
+		return exactFilter{v}, nil
+	}
+	return nil, patternError("unsupported value: %v", v)
+}
+
+func (f exactFilter) match(v interface{}, present bool) bool {
+	if n, ok := v.(json.Number); ok {
+		x, err := n.Float64()
+		return err == nil && f.v == x
+	}
+	return f.v == v
+}
+
+// affixFilter matches the strings with the given prefix or suffix.
+type affixFilter struct {
+	s      string
+	suffix bool
+	fold   bool
+}
+
+func (f affixFilter) match(v interface{}, present bool) bool {
+	s, ok := v.(string)
+	if !ok || len(s) < len(f.s) {
+		return false
+	}
+	if f.suffix {
+		s = s[len(s)-len(f.s):]
+	} else {
+		s = s[:len(f.s)]
+	}
+	if f.fold {
+		return strings.EqualFold(s, f.s)
+	}
+	return s == f.s
+}
+
+// foldFilter matches the strings equal under Unicode case-folding.
+type foldFilter string
+
+func (f foldFilter) match(v interface{}, present bool) bool {
+	s, ok := v.(string)
+	return ok && strings.EqualFold(s, string(f))
+}
+
+// wildcardFilter matches the strings against a pattern where * matches any
+// sequence of characters. A literal * is escaped with a backslash.
+type wildcardFilter string
+
+func (f wildcardFilter) match(v interface{}, present bool) bool {
+	s, ok := v.(string)
+	return ok && wildcard(string(f), s)
+}
+
+func wildcard(p, s string) bool {
+	// Backtracking on the last star only is enough, as in path.Match.
+	star, next := -1, 0
+	i, j := 0, 0
+	for j < len(s) {
+		switch {
+		case i < len(p) && p[i] == '*':
+			star, next = i, j
+			i++
+		case i+1 < len(p) && p[i] == '\\' && p[i+1] == s[j]:
+			i += 2
+			j++
+		case i < len(p) && p[i] != '\\' && p[i] == s[j]:
+			i++
+			j++
+		case star >= 0:
+			next++
+			i, j = star+1, next
+		default:
+			return false
+		}
+	}
+	for i < len(p) && p[i] == '*' {
+		i++
+	}
+	return i == len(p)
+}
+
+// anythingButFilter matches the present values none of the filters matches.
+type anythingButFilter []filter
+
+func compileAnythingBut(arg interface{}) (filter, error) {
+	var values []interface{}
+	switch v := arg.(type) {
+	case []interface{}:
+		values = v
+	case map[string]interface{}:
+		if len(v) != 1 {
+			return nil, patternError("anything-but must have a single operator")
+		}
+		for op, a := range v {
+			switch op {
+			case "prefix", "suffix", "equals-ignore-case", "wildcard":
+			default:
+				return nil, patternError("unsupported anything-but operator: %s", op)
+			}
+			// These operators accept a list of values in anything-but.
+			list, ok := a.([]interface{})
+			if !ok {
+				list = []interface{}{a}
+			}
+			for _, e := range list {
+				values = append(values, map[string]interface{}{op: e})
+			}
+		}
+	default:
+		values = []interface{}{v}
+	}
+	if len(values) == 0 {
+		return nil, patternError("empty anything-but")
+	}
+
+	f := make(anythingButFilter, 0, len(values))
+	for _, v := range values {
+		ff, err := compileFilter(v)
+		if err != nil {
+			return nil, err
+		}
+		f = append(f, ff)
+	}
+	return f, nil
+}
+
+func (f anythingButFilter) match(v interface{}, present bool) bool {
+	if !present {
+		return false
+	}
+	for _, ff := range f {
+		if ff.match(v, present) {
+			return false
+		}
+	}
+	return true
+}
+
+// numericFilter matches the numbers within a range.
+type numericFilter []numericBound
+
+type numericBound struct {
+	op string
+	v  float64
+}
+
+func compileNumeric(arg interface{}) (filter, error) {
+	list, ok := arg.([]interface{})
+	if !ok || len(list) == 0 || len(list)%2 != 0 || len(list) > 4 {
+		return nil, patternError("numeric needs one or two operator-value pairs")
+	}
+
+	var f numericFilter
+	for i := 0; i < len(list); i += 2 {
+		op, ok := list[i].(string)
+		switch op {
+		case "<", "<=", "=", ">", ">=":
+		default:
+			ok = false
+		}
+		n, isNumber := list[i+1].(json.Number)
+		if !ok || !isNumber {
+			return nil, patternError("invalid numeric pair: %v %v", list[i], list[i+1])
+		}
+		v, err := n.Float64()
+		if err != nil {
+			return nil, patternError("invalid number: %s", n)
+		}
+		f = append(f, numericBound{op, v})
+	}
+	return f, nil
+}
+
+func (f numericFilter) match(v interface{}, present bool) bool {
+	n, ok := v.(json.Number)
+	if !ok {
+		return false
+	}
+	x, err := n.Float64()
+	if err != nil {
+		return false
+	}
+	for _, b := range f {
+		var ok bool
+		switch b.op {
+		case "<":
+			ok = x < b.v
+		case "<=":
+			ok = x <= b.v
+		case "=":
+			ok = x == b.v
+		case ">":
+			ok = x > b.v
+		case ">=":
+			ok = x >= b.v
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// existsFilter matches the fields according to their presence.
+type existsFilter bool
+
+func (f existsFilter) match(v interface{}, present bool) bool {
+	return present == bool(f)
+}
+
+// cidrFilter matches the IP addresses within a network.
+type cidrFilter struct {
+	ipnet *net.IPNet
+}
+
+func (f cidrFilter) match(v interface{}, present bool) bool {
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+	ip := net.ParseIP(s)
+	return ip != nil && f.ipnet.Contains(ip)
+}
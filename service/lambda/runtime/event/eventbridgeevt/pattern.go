@@ -0,0 +1,247 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package eventbridgeevt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// Pattern is a compiled Amazon EventBridge event pattern. It can be used for
+// fast repeated matching, by multiple goroutines simultaneously.
+//
+// The supported filters are exact values, prefix, suffix, anything-but,
+// numeric, exists, cidr, equals-ignore-case and wildcard, as well as the $or
+// operator.
+// See also http://docs.aws.amazon.com/eventbridge/latest/userguide/eb-event-patterns.html
+type Pattern struct {
+	root *objectPattern
+}
+
+// Compile parses the JSON event pattern.
+func Compile(pattern []byte) (*Pattern, error) {
+	var v interface{}
+	if err := decodeJSON(pattern, &v); err != nil {
+		return nil, err
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, patternError("pattern must be a JSON object")
+	}
+	root, err := compileObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	return &Pattern{root}, nil
+}
+
+// MustCompile is like Compile but panics if the pattern cannot be parsed.
+func MustCompile(pattern string) *Pattern {
+	p, err := Compile([]byte(pattern))
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Match reports whether the raw JSON event matches the JSON event pattern.
+// Use Compile to match many events against the same pattern.
+func Match(pattern, data []byte) (bool, error) {
+	p, err := Compile(pattern)
+	if err != nil {
+		return false, err
+	}
+	return p.Match(data)
+}
+
+// Match reports whether the raw JSON event matches the pattern.
+func (p *Pattern) Match(data []byte) (bool, error) {
+	var v interface{}
+	if err := decodeJSON(data, &v); err != nil {
+		return false, err
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	return p.root.match(obj), nil
+}
+
+// MatchEvent reports whether the event matches the pattern. The event is any
+// value marshalling to a JSON event, such as an *Event or a
+// *cloudwatchschedevt.Event.
+func (p *Pattern) MatchEvent(evt interface{}) (bool, error) {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		return false, err
+	}
+	return p.Match(data)
+}
+
+func decodeJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+func patternError(format string, args ...interface{}) error {
+	return fmt.Errorf("eventbridgeevt: invalid pattern: "+format, args...)
+}
+
+// objectPattern matches a JSON object when all its fields match and, for each
+// $or operator, at least one of the alternatives matches.
+type objectPattern struct {
+	fields []*fieldPattern
+	or     [][]*objectPattern
+}
+
+// fieldPattern matches a field of a JSON object, either with a nested pattern
+// or with a list of filters, one of which at least must match.
+type fieldPattern struct {
+	key     string
+	nested  *objectPattern
+	filters []filter
+}
+
+func compileObject(obj map[string]interface{}) (*objectPattern, error) {
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	p := &objectPattern{}
+	for _, k := range keys {
+		switch v := obj[k].(type) {
+		case map[string]interface{}:
+			nested, err := compileObject(v)
+			if err != nil {
+				return nil, err
+			}
+			p.fields = append(p.fields, &fieldPattern{key: k, nested: nested})
+		case []interface{}:
+			if k == "$or" {
+				alts, err := compileOr(v)
+				if err != nil {
+					return nil, err
+				}
+				p.or = append(p.or, alts)
+				continue
+			}
+			if len(v) == 0 {
+				return nil, patternError("empty filter list for %q", k)
+			}
+			fp := &fieldPattern{key: k}
+			for _, f := range v {
+				ff, err := compileFilter(f)
+				if err != nil {
+					return nil, err
+				}
+				fp.filters = append(fp.filters, ff)
+			}
+			p.fields = append(p.fields, fp)
+		default:
+			return nil, patternError("%q must be an object or an array", k)
+		}
+	}
+	return p, nil
+}
+
+func compileOr(v []interface{}) ([]*objectPattern, error) {
+	if len(v) < 2 {
+		return nil, patternError("$or needs at least two alternatives")
+	}
+	alts := make([]*objectPattern, 0, len(v))
+	for _, alt := range v {
+		obj, ok := alt.(map[string]interface{})
+		if !ok {
+			return nil, patternError("$or alternatives must be objects")
+		}
+		p, err := compileObject(obj)
+		if err != nil {
+			return nil, err
+		}
+		alts = append(alts, p)
+	}
+	return alts, nil
+}
+
+func (p *objectPattern) match(obj map[string]interface{}) bool {
+	for _, f := range p.fields {
+		v, ok := obj[f.key]
+		if !f.match(v, ok) {
+			return false
+		}
+	}
+	for _, alts := range p.or {
+		matched := false
+		for _, alt := range alts {
+			if alt.match(obj) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *fieldPattern) match(v interface{}, present bool) bool {
+	if f.nested != nil {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			return f.nested.match(v)
+		case []interface{}:
+			for _, e := range v {
+				if obj, ok := e.(map[string]interface{}); ok && f.nested.match(obj) {
+					return true
+				}
+			}
+		}
+		// A missing object only matches a pattern made of exists filters
+		// which all expect the fields to be missing.
+		return !present && f.nested.match(map[string]interface{}{})
+	}
+
+	for _, ff := range f.filters {
+		if e, ok := ff.(existsFilter); ok {
+			if e.match(v, present) {
+				return true
+			}
+			continue
+		}
+		if !present {
+			continue
+		}
+		if arr, ok := v.([]interface{}); ok {
+			for _, e := range arr {
+				if ff.match(e, true) {
+					return true
+				}
+			}
+			continue
+		}
+		if ff.match(v, true) {
+			return true
+		}
+	}
+	return false
+}
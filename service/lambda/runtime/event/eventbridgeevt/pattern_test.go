@@ -0,0 +1,431 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package eventbridgeevt
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/cloudwatchschedevt"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		events  map[string]bool
+	}{
+		{
+			"exact",
+			`{"source":["aws.ec2"],"detail":{"state":["running","pending"]}}`,
+			map[string]bool{
+				`{"source":"aws.ec2","detail":{"state":"pending"}}`:   true,
+				`{"source":"aws.ec2","detail":{"state":"stopped"}}`:   false,
+				`{"source":"aws.ecs","detail":{"state":"pending"}}`:   false,
+				`{"source":"aws.ec2","detail":{"state":"Pending"}}`:   false,
+				`{"source":"aws.ec2"}`:                                false,
+				`{"source":"aws.ec2","detail":"pending"}`:             false,
+				`{"source":"aws.ec2","detail":{"state":["pending"]}}`: true,
+			},
+		},
+		{
+			"exact number, boolean and null",
+			`{"count":[5],"enabled":[true],"parent":[null]}`,
+			map[string]bool{
+				`{"count":5,"enabled":true,"parent":null}`:             true,
+				`{"count":5.0,"enabled":true,"parent":null}`:           true,
+				`{"count":5e0,"enabled":true,"parent":null}`:           true,
+				`{"count":"5","enabled":true,"parent":null}`:           false,
+				`{"count":5.01,"enabled":true,"parent":null}`:          false,
+				`{"count":5,"enabled":"true","parent":null}`:           false,
+				`{"count":5,"enabled":true,"parent":"null"}`:           false,
+				`{"count":5,"enabled":true}`:                           false,
+				`{"count":[4,5],"enabled":[false,true],"parent":null}`: true,
+			},
+		},
+		{
+			"prefix",
+			`{"region":[{"prefix":"ca-"}]}`,
+			map[string]bool{
+				`{"region":"ca-central-1"}`: true,
+				`{"region":"ca-"}`:          true,
+				`{"region":"CA-central-1"}`: false,
+				`{"region":"us-east-1"}`:    false,
+				`{"region":"ca"}`:           false,
+				`{"region":1}`:              false,
+				`{}`:                        false,
+			},
+		},
+		{
+			"prefix equals-ignore-case",
+			`{"region":[{"prefix":{"equals-ignore-case":"CA-"}}]}`,
+			map[string]bool{
+				`{"region":"ca-central-1"}`: true,
+				`{"region":"Ca-central-1"}`: true,
+				`{"region":"us-east-1"}`:    false,
+			},
+		},
+		{
+			"suffix",
+			`{"key":[{"suffix":".png"}]}`,
+			map[string]bool{
+				`{"key":"image.png"}`:     true,
+				`{"key":".png"}`:          true,
+				`{"key":"image.PNG"}`:     false,
+				`{"key":"image.png.txt"}`: false,
+				`{"key":"png"}`:           false,
+			},
+		},
+		{
+			"suffix equals-ignore-case",
+			`{"key":[{"suffix":{"equals-ignore-case":".PNG"}}]}`,
+			map[string]bool{
+				`{"key":"image.png"}`: true,
+				`{"key":"image.Png"}`: true,
+				`{"key":"image.jpg"}`: false,
+			},
+		},
+		{
+			"equals-ignore-case",
+			`{"name":[{"equals-ignore-case":"alice"}]}`,
+			map[string]bool{
+				`{"name":"ALICE"}`:  true,
+				`{"name":"Alice"}`:  true,
+				`{"name":"Alice "}`: false,
+				`{"name":"Bob"}`:    false,
+			},
+		},
+		{
+			"anything-but value",
+			`{"state":[{"anything-but":"initializing"}]}`,
+			map[string]bool{
+				`{"state":"running"}`:      true,
+				`{"state":"initializing"}`: false,
+				`{}`:                       false,
+			},
+		},
+		{
+			"anything-but list",
+			`{"state":[{"anything-but":["stopped","terminated"]}],"code":[{"anything-but":[100,200]}]}`,
+			map[string]bool{
+				`{"state":"running","code":300}`:    true,
+				`{"state":"stopped","code":300}`:    false,
+				`{"state":"terminated","code":300}`: false,
+				`{"state":"running","code":200.0}`:  false,
+				`{"state":"running","code":"200"}`:  true,
+			},
+		},
+		{
+			"anything-but prefix",
+			`{"state":[{"anything-but":{"prefix":"init"}}]}`,
+			map[string]bool{
+				`{"state":"running"}`:      true,
+				`{"state":"initializing"}`: false,
+				`{"state":"Initializing"}`: true,
+				`{}`:                       false,
+			},
+		},
+		{
+			"anything-but suffix",
+			`{"key":[{"anything-but":{"suffix":[".tmp",".bak"]}}]}`,
+			map[string]bool{
+				`{"key":"data.json"}`: true,
+				`{"key":"data.tmp"}`:  false,
+				`{"key":"data.bak"}`:  false,
+			},
+		},
+		{
+			"anything-but equals-ignore-case",
+			`{"name":[{"anything-but":{"equals-ignore-case":["alice","bob"]}}]}`,
+			map[string]bool{
+				`{"name":"Carol"}`: true,
+				`{"name":"ALICE"}`: false,
+				`{"name":"Bob"}`:   false,
+			},
+		},
+		{
+			"anything-but wildcard",
+			`{"key":[{"anything-but":{"wildcard":"tmp/*"}}]}`,
+			map[string]bool{
+				`{"key":"data/a.json"}`: true,
+				`{"key":"tmp/a.json"}`:  false,
+			},
+		},
+		{
+			"numeric",
+			`{"price":[{"numeric":[">",0,"<=",5]}]}`,
+			map[string]bool{
+				`{"price":5}`:     true,
+				`{"price":5.0}`:   true,
+				`{"price":0.5}`:   true,
+				`{"price":5.001}`: false,
+				`{"price":0}`:     false,
+				`{"price":-1}`:    false,
+				`{"price":"3"}`:   false,
+				`{"price":[9,3]}`: true,
+				`{}`:              false,
+			},
+		},
+		{
+			"numeric equality",
+			`{"count":[{"numeric":["=",5]}],"ratio":[{"numeric":[">=",1.5]}]}`,
+			map[string]bool{
+				`{"count":5,"ratio":1.5}`:     true,
+				`{"count":5.0,"ratio":15e-1}`: true,
+				`{"count":5,"ratio":1.49}`:    false,
+				`{"count":4.9,"ratio":2}`:     false,
+			},
+		},
+		{
+			"exists",
+			`{"detail":{"c-count":[{"exists":true}],"d-count":[{"exists":false}]}}`,
+			map[string]bool{
+				`{"detail":{"c-count":1}}`:                true,
+				`{"detail":{"c-count":null}}`:             true,
+				`{"detail":{"c-count":1,"d-count":1}}`:    false,
+				`{"detail":{"c-count":1,"d-count":null}}`: false,
+				`{"detail":{}}`:                           false,
+			},
+		},
+		{
+			"exists false",
+			`{"detail":{"d-count":[{"exists":false}]}}`,
+			map[string]bool{
+				`{"detail":{}}`:               true,
+				`{"detail":{"other":1}}`:      true,
+				`{"detail":{"d-count":null}}`: false,
+				`{"detail":{"d-count":0}}`:    false,
+				`{}`:                          true,
+			},
+		},
+		{
+			"cidr IPv4",
+			`{"detail":{"source-ip":[{"cidr":"10.0.0.0/24"}]}}`,
+			map[string]bool{
+				`{"detail":{"source-ip":"10.0.0.255"}}`: true,
+				`{"detail":{"source-ip":"10.0.0.1"}}`:   true,
+				`{"detail":{"source-ip":"10.0.1.1"}}`:   false,
+				`{"detail":{"source-ip":"10.0.0"}}`:     false,
+				`{"detail":{"source-ip":"::1"}}`:        false,
+				`{"detail":{"source-ip":167772161}}`:    false,
+			},
+		},
+		{
+			"cidr IPv6",
+			`{"detail":{"source-ip":[{"cidr":"2001:db8::/32"}]}}`,
+			map[string]bool{
+				`{"detail":{"source-ip":"2001:db8:1234:a100:8d6e:3477:df66:f105"}}`: true,
+				`{"detail":{"source-ip":"2001:DB8::1"}}`:                            true,
+				`{"detail":{"source-ip":"2001:db9::1"}}`:                            false,
+				`{"detail":{"source-ip":"10.0.0.1"}}`:                               false,
+			},
+		},
+		{
+			"wildcard",
+			`{"key":[{"wildcard":"dir/*.png"}]}`,
+			map[string]bool{
+				`{"key":"dir/a.png"}`:       true,
+				`{"key":"dir/.png"}`:        true,
+				`{"key":"dir/sub/a.png"}`:   true,
+				`{"key":"dir/a.png.png"}`:   true,
+				`{"key":"dir/a.png.jpg"}`:   false,
+				`{"key":"other/dir/a.png"}`: false,
+				`{"key":"dir/a.PNG"}`:       false,
+			},
+		},
+		{
+			"wildcard backtracking",
+			`{"key":[{"wildcard":"*ab*ab"}]}`,
+			map[string]bool{
+				`{"key":"abab"}`:       true,
+				`{"key":"xabyabzab"}`:  true,
+				`{"key":"ababa"}`:      false,
+				`{"key":"aab"}`:        false,
+				`{"key":"aabaaabaab"}`: true,
+			},
+		},
+		{
+			"wildcard escaped star",
+			`{"key":[{"wildcard":"a\\*b*"}]}`,
+			map[string]bool{
+				`{"key":"a*b"}`:   true,
+				`{"key":"a*bcd"}`: true,
+				`{"key":"axb"}`:   false,
+				`{"key":"ab"}`:    false,
+			},
+		},
+		{
+			"$or",
+			`{"source":["aws.ec2"],"$or":[{"detail":{"state":["running"]}},{"detail":{"code":[{"numeric":[">",500]}]}}]}`,
+			map[string]bool{
+				`{"source":"aws.ec2","detail":{"state":"running"}}`: true,
+				`{"source":"aws.ec2","detail":{"code":503}}`:        true,
+				`{"source":"aws.ec2","detail":{"state":"stopped"}}`: false,
+				`{"source":"aws.ecs","detail":{"state":"running"}}`: false,
+			},
+		},
+		{
+			"nested $or",
+			`{"$or":[{"a":[1]},{"$or":[{"b":[2]},{"c":{"$or":[{"d":[3]},{"e":[{"exists":true}]}]}}]}]}`,
+			map[string]bool{
+				`{"a":1}`:           true,
+				`{"b":2}`:           true,
+				`{"c":{"d":3}}`:     true,
+				`{"c":{"e":false}}`: true,
+				`{"c":{"d":4}}`:     false,
+				`{"a":2,"b":1}`:     false,
+				`{}`:                false,
+			},
+		},
+		{
+			"arrays",
+			`{"resources":["arn:aws:ec2:us-east-1:123456789012:instance/i-abcd1111"],"detail":{"containers":{"lastStatus":["STOPPED"]}}}`,
+			map[string]bool{
+				`{"resources":["arn:aws:ec2:us-east-1:123456789012:instance/i-abcd2222","arn:aws:ec2:us-east-1:123456789012:instance/i-abcd1111"],"detail":{"containers":[{"lastStatus":"RUNNING"},{"lastStatus":"STOPPED"}]}}`: true,
+				`{"resources":["arn:aws:ec2:us-east-1:123456789012:instance/i-abcd1111"],"detail":{"containers":[{"lastStatus":"RUNNING"}]}}`:                                                                                   false,
+				`{"resources":[],"detail":{"containers":[{"lastStatus":"STOPPED"}]}}`:                                                                                                                                           false,
+				`{"resources":["arn:aws:ec2:us-east-1:123456789012:instance/i-abcd1111"],"detail":{"containers":[]}}`:                                                                                                           false,
+			},
+		},
+		{
+			"missing object",
+			`{"detail":{"error":[{"exists":false}]}}`,
+			map[string]bool{
+				`{}`:                       true,
+				`{"detail":{}}`:            true,
+				`{"detail":{"error":"x"}}`: false,
+				`{"detail":"x"}`:           false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		p, err := Compile([]byte(tt.pattern))
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		for evt, want := range tt.events {
+			got, err := p.Match([]byte(evt))
+			if err != nil {
+				t.Errorf("%s: %s: %v", tt.name, evt, err)
+				continue
+			}
+			if got != want {
+				t.Errorf("%s: %s: got %t, want %t", tt.name, evt, got, want)
+			}
+		}
+	}
+}
+
+func TestMatchNotObject(t *testing.T) {
+	for _, evt := range []string{`[]`, `"event"`, `null`, `1`} {
+		if ok, err := Match([]byte(`{"a":[{"exists":false}]}`), []byte(evt)); ok || err != nil {
+			t.Errorf("%s: got %t, %v", evt, ok, err)
+		}
+	}
+	if _, err := Match([]byte(`{"a":["b"]}`), []byte(`{"a":`)); err == nil {
+		t.Error("got no error for an invalid event")
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	tests := map[string]string{
+		`{"a":`:                               "unexpected EOF",
+		`[]`:                                  "eventbridgeevt: invalid pattern: pattern must be a JSON object",
+		`{"a":"b"}`:                           `eventbridgeevt: invalid pattern: "a" must be an object or an array`,
+		`{"a":1}`:                             `eventbridgeevt: invalid pattern: "a" must be an object or an array`,
+		`{"a":[]}`:                            `eventbridgeevt: invalid pattern: empty filter list for "a"`,
+		`{"a":[[1]]}`:                         "eventbridgeevt: invalid pattern: unsupported value: [1]",
+		`{"a":[{}]}`:                          "eventbridgeevt: invalid pattern: filter must have a single operator",
+		`{"a":[{"prefix":"x","suffix":"y"}]}`: "eventbridgeevt: invalid pattern: filter must have a single operator",
+		`{"a":[{"regex":"x"}]}`:               "eventbridgeevt: invalid pattern: unsupported operator: regex",
+		`{"a":[{"prefix":1}]}`:                "eventbridgeevt: invalid pattern: prefix needs a string",
+		`{"a":[{"suffix":{"equals-ignore-case":1}}]}`:          "eventbridgeevt: invalid pattern: suffix needs a string",
+		`{"a":[{"equals-ignore-case":["x"]}]}`:                 "eventbridgeevt: invalid pattern: equals-ignore-case needs a string",
+		`{"a":[{"wildcard":1}]}`:                               "eventbridgeevt: invalid pattern: wildcard needs a string",
+		`{"a":[{"exists":"true"}]}`:                            "eventbridgeevt: invalid pattern: exists needs a boolean",
+		`{"a":[{"cidr":"10.0.0.0"}]}`:                          "eventbridgeevt: invalid pattern: invalid cidr: 10.0.0.0",
+		`{"a":[{"cidr":24}]}`:                                  "eventbridgeevt: invalid pattern: cidr needs a string",
+		`{"a":[{"numeric":[">"]}]}`:                            "eventbridgeevt: invalid pattern: numeric needs one or two operator-value pairs",
+		`{"a":[{"numeric":[">",1,"<",2,"=",3]}]}`:              "eventbridgeevt: invalid pattern: numeric needs one or two operator-value pairs",
+		`{"a":[{"numeric":["!=",1]}]}`:                         "eventbridgeevt: invalid pattern: invalid numeric pair: != 1",
+		`{"a":[{"numeric":[">","1"]}]}`:                        "eventbridgeevt: invalid pattern: invalid numeric pair: > 1",
+		`{"a":[{"anything-but":[]}]}`:                          "eventbridgeevt: invalid pattern: empty anything-but",
+		`{"a":[{"anything-but":{"numeric":[">",1]}}]}`:         "eventbridgeevt: invalid pattern: unsupported anything-but operator: numeric",
+		`{"a":[{"anything-but":{"prefix":"x","suffix":"y"}}]}`: "eventbridgeevt: invalid pattern: anything-but must have a single operator",
+		`{"a":[{"anything-but":{"prefix":1}}]}`:                "eventbridgeevt: invalid pattern: prefix needs a string",
+		`{"$or":[{"a":["b"]}]}`:                                "eventbridgeevt: invalid pattern: $or needs at least two alternatives",
+		`{"$or":[{"a":["b"]},"c"]}`:                            "eventbridgeevt: invalid pattern: $or alternatives must be objects",
+		`{"$or":[{"a":["b"]},{"c":[]}]}`:                       `eventbridgeevt: invalid pattern: empty filter list for "c"`,
+		`{"a":{"b":{"c":[{"prefix":1}]}}}`:                     "eventbridgeevt: invalid pattern: prefix needs a string",
+	}
+
+	for pattern, want := range tests {
+		if _, err := Compile([]byte(pattern)); err == nil || err.Error() != want {
+			t.Errorf("%s: got %v, want %s", pattern, err, want)
+		}
+	}
+}
+
+func TestMustCompile(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("got no panic")
+		}
+	}()
+	MustCompile(`{"a":[]}`)
+}
+
+func TestMatchEvent(t *testing.T) {
+	data, err := ioutil.ReadFile("../cloudwatchschedevt/testdata/event-scheduled.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sched cloudwatchschedevt.Event
+	if err := json.Unmarshal(data, &sched); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]bool{
+		`{"source":["aws.events"],"detail-type":["Scheduled Event"]}`:              true,
+		`{"resources":[{"prefix":"arn:aws:events:us-east-1:123456789012:rule/"}]}`: true,
+		`{"time":[{"prefix":"2015-10-08T16:53"}]}`:                                 true,
+		`{"source":["aws.events"],"detail":{"state":[{"exists":true}]}}`:           false,
+		`{"source":["aws.events"],"detail":{"state":[{"exists":false}]}}`:          true,
+		`{"source":[{"anything-but":"aws.events"}]}`:                               false,
+	}
+	for pattern, want := range tests {
+		got, err := MustCompile(pattern).MatchEvent(&sched)
+		if err != nil {
+			t.Errorf("%s: %v", pattern, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("%s: got %t, want %t", pattern, got, want)
+		}
+	}
+
+	ec2 := readEvent(t, "testdata/event-ec2.json")
+	if ok, err := MustCompile(`{"detail":{"state":["pending"]}}`).MatchEvent(ec2); !ok || err != nil {
+		t.Errorf("got %t, %v for an *Event", ok, err)
+	}
+	if _, err := MustCompile(`{"a":["b"]}`).MatchEvent(func() {}); err == nil {
+		t.Error("got no error for a value which cannot be marshalled")
+	}
+}
@@ -0,0 +1,84 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package eventbridgeevt
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// UnregisteredDetailError is returned by DecodeDetail when no Go type has been
+// registered for the source and detail type of the event.
+type UnregisteredDetailError struct {
+	Source     string
+	DetailType string
+}
+
+func (e *UnregisteredDetailError) Error() string {
+	return fmt.Sprintf("eventbridgeevt: no detail type registered for source %q and detail type %q", e.Source, e.DetailType)
+}
+
+type detailKey struct {
+	source     string
+	detailType string
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[detailKey]reflect.Type)
+)
+
+// RegisterDetail registers the Go type of the detail of the events with the
+// given source and detail type, such as a custom event bus application would
+// send. The type is given by an example value, either a struct or a pointer to
+// a struct. Registering a type for a pair already registered replaces it.
+func RegisterDetail(source, detailType string, v interface{}) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		panic("eventbridgeevt: RegisterDetail of nil type")
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[detailKey{source, detailType}] = t
+}
+
+// DecodeDetail decodes the detail of the event to a new value of the Go type
+// registered for its source and detail type, and returns a pointer to it.
+// It returns an *UnregisteredDetailError if no type has been registered.
+func (e *Event) DecodeDetail() (interface{}, error) {
+	registryMu.RLock()
+	t, ok := registry[detailKey{e.Source, e.DetailType}]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, &UnregisteredDetailError{e.Source, e.DetailType}
+	}
+
+	v := reflect.New(t).Interface()
+	if len(e.Detail) == 0 {
+		return v, nil
+	}
+	if err := json.Unmarshal(e.Detail, v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
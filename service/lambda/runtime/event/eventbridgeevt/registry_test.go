@@ -0,0 +1,200 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package eventbridgeevt
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func readEvent(t *testing.T, file string) *Event {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var evt Event
+	if err := json.Unmarshal(data, &evt); err != nil {
+		t.Fatal(err)
+	}
+	return &evt
+}
+
+func TestDecodeDetail(t *testing.T) {
+	createdAt := time.Date(2020, 1, 23, 17, 57, 34, 402e6, time.UTC)
+	startedAt := time.Date(2020, 1, 23, 17, 57, 58, 103e6, time.UTC)
+
+	tests := []struct {
+		file string
+		want interface{}
+	}{
+		{
+			"testdata/event-ec2.json",
+			&EC2InstanceStateChange{InstanceID: "i-abcd1111", State: "pending"},
+		},
+		{
+			"testdata/event-ecs.json",
+			&ECSTaskStateChange{
+				ClusterARN:        "arn:aws:ecs:us-west-2:111122223333:cluster/FargateCluster",
+				TaskARN:           "arn:aws:ecs:us-west-2:111122223333:task/FargateCluster/c13b4cb40f1f4fe4a2971f76ae5a47ad",
+				TaskDefinitionARN: "arn:aws:ecs:us-west-2:111122223333:task-definition/sample-fargate:1",
+				Group:             "family:sample-fargate",
+				LaunchType:        "FARGATE",
+				LastStatus:        "RUNNING",
+				DesiredStatus:     "RUNNING",
+				Containers: []*ECSContainer{{
+					ContainerARN: "arn:aws:ecs:us-west-2:111122223333:container/cf159fd6-3e3f-4a9e-84f9-66cbe726af01",
+					Name:         "FargateApp",
+					LastStatus:   "RUNNING",
+					TaskARN:      "arn:aws:ecs:us-west-2:111122223333:task/FargateCluster/c13b4cb40f1f4fe4a2971f76ae5a47ad",
+				}},
+				CreatedAt: createdAt,
+				StartedAt: &startedAt,
+				Version:   3,
+			},
+		},
+		{
+			"testdata/event-s3.json",
+			&S3ObjectEvent{
+				Version: "0",
+				Bucket:  S3Bucket{Name: "DOC-EXAMPLE-BUCKET1"},
+				Object: S3Object{
+					Key:       "example-key",
+					Size:      5,
+					ETag:      "b1946ac92492d2347c6235b4d2611184",
+					VersionID: "IYV3p45BT0ac8hjHg1houSdS1a.Mro8e",
+					Sequencer: "617f08299329d189",
+				},
+				RequestID:       "N4N7GDK58NMKJ12R",
+				Requester:       "123456789012",
+				SourceIPAddress: "1.2.3.4",
+				Reason:          "PutObject",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := readEvent(t, tt.file).DecodeDetail()
+		if err != nil {
+			t.Errorf("%s: %v", tt.file, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestDecodeDetailCodeBuild(t *testing.T) {
+	v, err := readEvent(t, "testdata/event-codebuild.json").DecodeDetail()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, ok := v.(*CodeBuildStateChange)
+	if !ok {
+		t.Fatalf("got %T, want *CodeBuildStateChange", v)
+	}
+	if d.BuildStatus != "SUCCEEDED" || d.ProjectName != "my-sample-project" || d.CurrentPhase != "COMPLETED" || d.CurrentPhaseContext != "[]" || d.Version != "1" {
+		t.Errorf("got %+v", d)
+	}
+
+	var info struct {
+		BuildComplete bool `json:"build-complete"`
+		Initiator     string
+	}
+	if err := json.Unmarshal(d.AdditionalInformation, &info); err != nil {
+		t.Fatal(err)
+	}
+	if !info.BuildComplete || info.Initiator != "MyCodeBuildDemoUser" {
+		t.Errorf("got additional information %+v", info)
+	}
+}
+
+type order struct {
+	OrderID string   `json:"orderId"`
+	Amount  float64  `json:"amount"`
+	Items   []string `json:"items"`
+}
+
+func TestRegisterDetail(t *testing.T) {
+	evt := readEvent(t, "testdata/event-custom.json")
+
+	_, err := evt.DecodeDetail()
+	uerr, ok := err.(*UnregisteredDetailError)
+	if !ok {
+		t.Fatalf("got %v, want an *UnregisteredDetailError", err)
+	}
+	if uerr.Source != "com.example.orders" || uerr.DetailType != "Order Placed" {
+		t.Errorf("got %+v", uerr)
+	}
+	if want := `eventbridgeevt: no detail type registered for source "com.example.orders" and detail type "Order Placed"`; err.Error() != want {
+		t.Errorf("got %q, want %q", err, want)
+	}
+
+	want := &order{OrderID: "1001", Amount: 42.5, Items: []string{"book", "pen"}}
+	for _, v := range []interface{}{order{}, &order{}} {
+		RegisterDetail("com.example.orders", "Order Placed", v)
+		got, err := evt.DecodeDetail()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	}
+
+	// Registering a type again replaces the previous one.
+	RegisterDetail("com.example.orders", "Order Placed", map[string]interface{}{})
+	if got, err := evt.DecodeDetail(); err != nil {
+		t.Error(err)
+	} else if _, ok := got.(*map[string]interface{}); !ok {
+		t.Errorf("got %T, want *map[string]interface {}", got)
+	}
+
+	// The source and detail type are matched exactly.
+	evt.DetailType = "order placed"
+	if _, err := evt.DecodeDetail(); err == nil {
+		t.Error("got no error for an unregistered detail type")
+	}
+}
+
+func TestDecodeDetailEmpty(t *testing.T) {
+	evt := &Event{Source: "aws.ec2", DetailType: "EC2 Instance State-change Notification"}
+	got, err := evt.DecodeDetail()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, &EC2InstanceStateChange{}) {
+		t.Errorf("got %+v", got)
+	}
+
+	evt.Detail = json.RawMessage(`{"state":3}`)
+	if _, err := evt.DecodeDetail(); err == nil {
+		t.Error("got no error for an invalid detail")
+	}
+}
+
+func TestRegisterDetailNil(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("got no panic")
+		}
+	}()
+	RegisterDetail("com.example", "Nil", nil)
+}
@@ -0,0 +1,64 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package batch provides the processing of event records shared by the
+// partial batch responses of the stream and queue event packages.
+package batch
+
+import (
+	"context"
+	"fmt"
+)
+
+// Process calls h for the records 0 to n-1, in order, and returns the indexes
+// of the records which failed: those whose handler returns an error or panics,
+// and those not processed because ctx is done.
+// When ordered reports that a failed record belongs to an ordered sequence,
+// Process stops there and reports all the remaining records as failed.
+func Process(ctx context.Context, n int, ordered func(i int) bool, h func(ctx context.Context, i int) error) []int {
+	failed := []int{}
+	for i := 0; i < n; i++ {
+		err := ctx.Err()
+		if err == nil {
+			err = call(ctx, h, i)
+		}
+		if err == nil {
+			continue
+		}
+		if ordered(i) {
+			for ; i < n; i++ {
+				failed = append(failed, i)
+			}
+			break
+		}
+		failed = append(failed, i)
+	}
+	return failed
+}
+
+// Ordered reports every record as belonging to an ordered sequence.
+func Ordered(int) bool {
+	return true
+}
+
+func call(ctx context.Context, h func(ctx context.Context, i int) error, i int) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("panic: %v", v)
+		}
+	}()
+	return h(ctx, i)
+}
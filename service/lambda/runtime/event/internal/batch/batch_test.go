@@ -0,0 +1,147 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package batch
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestProcess(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name    string
+		fail    map[int]bool
+		panics  map[int]bool
+		ordered map[int]bool
+		called  []int
+		failed  []int
+	}{
+		{
+			name:   "success",
+			called: []int{0, 1, 2, 3},
+			failed: []int{},
+		},
+		{
+			name:   "unordered failures",
+			fail:   map[int]bool{1: true, 3: true},
+			called: []int{0, 1, 2, 3},
+			failed: []int{1, 3},
+		},
+		{
+			name:    "ordered failure",
+			fail:    map[int]bool{1: true, 3: true},
+			ordered: map[int]bool{0: true, 1: true, 2: true, 3: true},
+			called:  []int{0, 1},
+			failed:  []int{1, 2, 3},
+		},
+		{
+			name:    "ordered failure of the first record",
+			fail:    map[int]bool{0: true},
+			ordered: map[int]bool{0: true, 1: true, 2: true, 3: true},
+			called:  []int{0},
+			failed:  []int{0, 1, 2, 3},
+		},
+		{
+			name:    "ordered failure of the last record",
+			fail:    map[int]bool{3: true},
+			ordered: map[int]bool{0: true, 1: true, 2: true, 3: true},
+			called:  []int{0, 1, 2, 3},
+			failed:  []int{3},
+		},
+		{
+			name:    "unordered then ordered failure",
+			fail:    map[int]bool{0: true, 2: true},
+			ordered: map[int]bool{2: true},
+			called:  []int{0, 1, 2},
+			failed:  []int{0, 2, 3},
+		},
+		{
+			name:   "panic",
+			panics: map[int]bool{2: true},
+			called: []int{0, 1, 2, 3},
+			failed: []int{2},
+		},
+		{
+			name:    "ordered panic",
+			panics:  map[int]bool{2: true},
+			ordered: map[int]bool{2: true},
+			called:  []int{0, 1, 2},
+			failed:  []int{2, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := []int{}
+			ordered := func(i int) bool { return tt.ordered[i] }
+			failed := Process(context.Background(), 4, ordered, func(ctx context.Context, i int) error {
+				called = append(called, i)
+				if tt.panics[i] {
+					panic("boom")
+				}
+				if tt.fail[i] {
+					return errFailed
+				}
+				return nil
+			})
+
+			if !reflect.DeepEqual(called, tt.called) {
+				t.Errorf("got calls %v, want %v", called, tt.called)
+			}
+			if !reflect.DeepEqual(failed, tt.failed) {
+				t.Errorf("got failures %v, want %v", failed, tt.failed)
+			}
+		})
+	}
+}
+
+func TestProcessCanceled(t *testing.T) {
+	for _, ordered := range []bool{false, true} {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var called int
+		failed := Process(ctx, 4, func(int) bool { return ordered }, func(ctx context.Context, i int) error {
+			called++
+			if i == 1 {
+				cancel()
+			}
+			return nil
+		})
+		cancel()
+
+		if called != 2 {
+			t.Errorf("ordered %t: got %d calls, want 2", ordered, called)
+		}
+		if want := []int{2, 3}; !reflect.DeepEqual(failed, want) {
+			t.Errorf("ordered %t: got failures %v, want %v", ordered, failed, want)
+		}
+	}
+}
+
+func TestProcessEmpty(t *testing.T) {
+	failed := Process(context.Background(), 0, Ordered, func(ctx context.Context, i int) error {
+		t.Error("unexpected call")
+		return nil
+	})
+	if failed == nil || len(failed) != 0 {
+		t.Errorf("got failures %#v, want an empty list", failed)
+	}
+}
@@ -0,0 +1,189 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package eventtest provides helpers shared by the tests of the event
+// packages.
+package eventtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// RoundTrip decodes each JSON payload matching pattern into the value returned
+// by newValue, encodes it back and reports an error unless the output is the
+// same JSON document as the payload and decodes to the same value.
+//
+// Documents are compared semantically: object member order and insignificant
+// whitespace are ignored, numbers are compared by their literal. A null in the
+// payload matches an absent member or the zero value of a field that cannot
+// hold null.
+func RoundTrip(t *testing.T, pattern string, newValue func() interface{}) {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatalf("no payload matches %s", pattern)
+	}
+
+	for _, file := range files {
+		file := file
+		t.Run(strings.TrimSuffix(filepath.Base(file), ".json"), func(t *testing.T) {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			data = compact(data)
+
+			x := newValue()
+			if err := json.Unmarshal(data, x); err != nil {
+				t.Fatal(err)
+			}
+
+			out, err := json.Marshal(x)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := Equal(out, data); err != nil {
+				t.Errorf("%v\n got %s\nwant %s", err, out, data)
+			}
+
+			y := newValue()
+			if err := json.Unmarshal(out, y); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(x, y) {
+				t.Errorf("round trip mismatch:\n got %s\nwant %s", y, x)
+			}
+		})
+	}
+}
+
+// Equal returns an error describing the first difference between the JSON
+// documents got and want, or nil if they are the same under the rules of
+// RoundTrip.
+func Equal(got, want []byte) error {
+	g, err := decode(got)
+	if err != nil {
+		return err
+	}
+	w, err := decode(want)
+	if err != nil {
+		return err
+	}
+	return diff("$", g, w)
+}
+
+func decode(data []byte) (interface{}, error) {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func diff(path string, got, want interface{}) error {
+	if want == nil {
+		if !zero(got) {
+			return fmt.Errorf("%s: got %v, want null", path, got)
+		}
+		return nil
+	}
+
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: got %v, want object", path, got)
+		}
+		keys := make([]string, 0, len(g)+len(w))
+		for k := range w {
+			keys = append(keys, k)
+		}
+		for k := range g {
+			if _, ok := w[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			gv, gok := g[k]
+			wv, wok := w[k]
+			switch {
+			case !wok:
+				return fmt.Errorf("%s.%s: unexpected member", path, k)
+			case !gok && wv != nil:
+				return fmt.Errorf("%s.%s: missing member", path, k)
+			}
+			if err := diff(path+"."+k, gv, wv); err != nil {
+				return err
+			}
+		}
+		return nil
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok || len(g) != len(w) {
+			return fmt.Errorf("%s: got %v, want %d elements", path, got, len(w))
+		}
+		for i := range w {
+			if err := diff(fmt.Sprintf("%s[%d]", path, i), g[i], w[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		if got != want {
+			return fmt.Errorf("%s: got %v, want %v", path, got, want)
+		}
+		return nil
+	}
+}
+
+func zero(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	case json.Number:
+		return v == "0"
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+func compact(data []byte) []byte {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return data
+	}
+	return buf.Bytes()
+}
@@ -24,6 +24,8 @@ import (
 
 type recordAlias InputRecord
 
+type kinesisRecordMetadataAlias KinesisRecordMetadata
+
 type timestamp struct {
 	time.Time
 }
@@ -36,25 +38,31 @@ func (t *timestamp) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	t.Time = time.Unix(0, v*int64(time.Millisecond))
+	t.Time = time.Unix(v/1e3, v%1e3*int64(time.Millisecond))
 	return nil
 }
 
+// MarshalJSON returns t as the number of milliseconds elapsed since January 1,
+// 1970 00:00:00 UTC.
+func (t timestamp) MarshalJSON() ([]byte, error) {
+	v := t.Unix()*1e3 + int64(t.Nanosecond())/int64(time.Millisecond)
+	return []byte(strconv.FormatInt(v, 10)), nil
+}
+
 type jsonRecord struct {
 	*recordAlias
-	ApproximateArrivalTimestamp timestamp
+	ApproximateArrivalTimestamp timestamp `json:"approximateArrivalTimestamp"`
 }
 
 // UnmarshalJSON interprets data as a Record with a special timestamp. It then
 // leverages type aliasing and struct embedding to fill Record with an usual
 // time.Time.
 func (r *InputRecord) UnmarshalJSON(data []byte) error {
-	var jr jsonRecord
+	jr := jsonRecord{recordAlias: (*recordAlias)(r)}
 	if err := json.Unmarshal(data, &jr); err != nil {
 		return err
 	}
 
-	*r = *(*InputRecord)(jr.recordAlias)
 	r.ApproximateArrivalTimestamp = jr.ApproximateArrivalTimestamp.Time
 
 	return nil
@@ -68,3 +76,31 @@ func (r *InputRecord) MarshalJSON() ([]byte, error) {
 		timestamp{r.ApproximateArrivalTimestamp},
 	})
 }
+
+type jsonKinesisRecordMetadata struct {
+	*kinesisRecordMetadataAlias
+	ApproximateArrivalTimestamp timestamp `json:"approximateArrivalTimestamp"`
+}
+
+// UnmarshalJSON interprets data as a KinesisRecordMetadata with a special
+// timestamp. It then leverages type aliasing and struct embedding to fill
+// KinesisRecordMetadata with an usual time.Time.
+func (m *KinesisRecordMetadata) UnmarshalJSON(data []byte) error {
+	jm := jsonKinesisRecordMetadata{kinesisRecordMetadataAlias: (*kinesisRecordMetadataAlias)(m)}
+	if err := json.Unmarshal(data, &jm); err != nil {
+		return err
+	}
+
+	m.ApproximateArrivalTimestamp = jm.ApproximateArrivalTimestamp.Time
+
+	return nil
+}
+
+// MarshalJSON reverts the effect of type aliasing and struct embedding used
+// during the marshalling step to make the pattern seamless.
+func (m *KinesisRecordMetadata) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonKinesisRecordMetadata{
+		(*kinesisRecordMetadataAlias)(m),
+		timestamp{m.ApproximateArrivalTimestamp},
+	})
+}
@@ -0,0 +1,134 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kinesisfirehoseevt
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		data string
+		want time.Time
+	}{
+		{"1440442987000", time.Unix(1440442987, 0)},
+		{"1440442987001", time.Unix(1440442987, 1e6)},
+		{"1495072949453", time.Unix(1495072949, 453e6)},
+		{"0", time.Unix(0, 0)},
+		{"-1500", time.Unix(-1, -5e8)},
+	}
+
+	for _, tt := range tests {
+		var ts timestamp
+		if err := json.Unmarshal([]byte(tt.data), &ts); err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if !ts.Equal(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.data, ts.Time, tt.want)
+		}
+
+		enc, err := json.Marshal(ts)
+		if err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if string(enc) != tt.data {
+			t.Errorf("%s: got %s", tt.data, enc)
+		}
+	}
+}
+
+func TestTimestampInvalid(t *testing.T) {
+	for _, data := range []string{`"1440442987000"`, `1440442987.5`, `null`} {
+		var ts timestamp
+		if err := json.Unmarshal([]byte(data), &ts); err == nil {
+			t.Errorf("%s: expected error", data)
+		}
+	}
+}
+
+func TestKinesisRecordMetadata(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/input-kinesis-source.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var in Input
+	if err := json.Unmarshal(data, &in); err != nil {
+		t.Fatal(err)
+	}
+
+	if in.SourceKinesisStreamARN != "arn:aws:kinesis:us-east-1:123456789012:stream/source" {
+		t.Errorf("got source stream %q", in.SourceKinesisStreamARN)
+	}
+	want := []*KinesisRecordMetadata{
+		{
+			ShardID:                     "shardId-000000000000",
+			PartitionKey:                "4d1ad2b9-24f8-4b9d-a088-76e9947c317a",
+			SequenceNumber:              "49546986683135544286507457936321625675700192471156785154",
+			ApproximateArrivalTimestamp: time.Unix(1495072949, 1e6),
+		},
+		{
+			ShardID:                     "shardId-000000000001",
+			PartitionKey:                "AMZN",
+			SequenceNumber:              "49546986683135544286507457936321625675700192471156785155",
+			SubsequenceNumber:           2,
+			ApproximateArrivalTimestamp: time.Unix(1495072949, 575e6),
+		},
+	}
+	if len(in.Records) != len(want) {
+		t.Fatalf("got %d records, want %d", len(in.Records), len(want))
+	}
+	for i, rec := range in.Records {
+		got := rec.KinesisRecordMetadata
+		if got == nil {
+			t.Errorf("record %d: no Amazon Kinesis metadata", i)
+			continue
+		}
+		if !got.ApproximateArrivalTimestamp.Equal(want[i].ApproximateArrivalTimestamp) {
+			t.Errorf("record %d: got arrival %v, want %v", i, got.ApproximateArrivalTimestamp, want[i].ApproximateArrivalTimestamp)
+		}
+		got.ApproximateArrivalTimestamp = want[i].ApproximateArrivalTimestamp
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("record %d: got %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestKinesisRecordMetadataDirectPut(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/input-direct-put.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var in Input
+	if err := json.Unmarshal(data, &in); err != nil {
+		t.Fatal(err)
+	}
+
+	if in.SourceKinesisStreamARN != "" {
+		t.Errorf("got source stream %q", in.SourceKinesisStreamARN)
+	}
+	for i, rec := range in.Records {
+		if rec.KinesisRecordMetadata != nil {
+			t.Errorf("record %d: got %+v", i, rec.KinesisRecordMetadata)
+		}
+	}
+}
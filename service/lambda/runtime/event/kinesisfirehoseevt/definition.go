@@ -21,6 +21,21 @@ import (
 	"time"
 )
 
+// Result is the status of the data transformation of a record.
+type Result string
+
+// The statuses of the data transformation of a record.
+const (
+	// The record was transformed successfully.
+	ResultOk Result = "Ok"
+
+	// The record was dropped intentionally by the processing logic.
+	ResultDropped Result = "Dropped"
+
+	// The record could not be transformed.
+	ResultProcessingFailed Result = "ProcessingFailed"
+)
+
 // OutputRecord represents the transformed Amazon Kinesis Firehose record
 type OutputRecord struct {
 	// The record ID is passed from Amazon Kinesis Firehose to AWS Lambda
@@ -30,20 +45,29 @@ type OutputRecord struct {
 	// failure.
 	RecordID string `json:"recordId"`
 
-	// The status of the data transformation of the record. The possible
-	// values are:
-	// - "Ok": the record was transformed successfully.
-	// - "Dropped": the record was dropped intentionally by your processing
-	//   logic.
-	// - "ProcessingFailed": the record could not be transformed.
-	// If a record has a status of "Ok" or "Dropped",
+	// The status of the data transformation of the record.
+	// If a record has a status of ResultOk or ResultDropped,
 	// Amazon Kinesis firehose considers it successfully processed.
 	// Otherwise, Amazon Kinesis Firehose considers it unsuccessfully
 	// processed.
-	Result string `json:"result"`
+	Result Result `json:"result"`
 
 	// The transformed data payload, after base64-encoding.
 	Data []byte `json:"data"`
+
+	// The metadata used by Amazon Kinesis Firehose dynamic partitioning.
+	// Only relevant when dynamic partitioning is enabled on the delivery
+	// stream, otherwise nil.
+	Metadata *Metadata `json:"metadata,omitempty"`
+}
+
+// Metadata provides the information Amazon Kinesis Firehose uses to deliver a
+// transformed record.
+type Metadata struct {
+	// The partition keys of the record, by name. They can be referenced in
+	// the S3 bucket prefix of the delivery stream with the
+	// !{partitionKeyFromLambda:name} expression.
+	PartitionKeys map[string]string `json:"partitionKeys"`
 }
 
 // Output represents the result of the processing of Amazon Kinesis Firehose
@@ -53,11 +77,32 @@ type Output struct {
 	Records []*OutputRecord `json:"records"`
 }
 
+// KinesisRecordMetadata provides information about the Amazon Kinesis Streams
+// record an Amazon Kinesis Firehose record has been read from.
+type KinesisRecordMetadata struct {
+	// The identifier of the shard the record has been read from.
+	ShardID string `json:"shardId"`
+
+	// The partition key of the record in the shard.
+	PartitionKey string `json:"partitionKey"`
+
+	// The sequence number of the record in the shard.
+	SequenceNumber string `json:"sequenceNumber"`
+
+	// The sequence number of the user record within the aggregated record,
+	// or 0 if the record is not aggregated.
+	SubsequenceNumber int64 `json:"subsequenceNumber"`
+
+	// The approximate time that the record was inserted into the
+	// Amazon Kinesis stream.
+	ApproximateArrivalTimestamp time.Time `json:"-"`
+}
+
 // InputRecord represents the unit of data of an Amazon Kinesis Firehose event.
 type InputRecord struct {
 	// The unique identifier of the record passed from
 	// Amazon Kinesis Firehose to AWS Lambda.
-	RecordID string
+	RecordID string `json:"recordId"`
 
 	// The approximate time that the record was inserted into the
 	// Amazon Kinesis Firehose delivery stream. This is set when a delivery
@@ -67,7 +112,7 @@ type InputRecord struct {
 	// increasing. For example, records in a specific
 	// Amazon Kinesis Firehose delivery stream might have timestamps that
 	// are out of order.
-	ApproximateArrivalTimestamp time.Time
+	ApproximateArrivalTimestamp time.Time `json:"-"`
 
 	// The data blob. The data in the blob is both opaque and immutable to
 	// the Amazon Kinesis Firehose service, which does not inspect,
@@ -75,23 +120,33 @@ type InputRecord struct {
 	// consists of any kind of data and the total size must not exceed the
 	// maximum record size (1 MB).
 	// Data is automatically base64 encoded/decoded by the SDK.
-	Data []byte
+	Data []byte `json:"data"`
+
+	// The metadata of the Amazon Kinesis Streams record the record has
+	// been read from. Provided when the delivery stream source is an
+	// Amazon Kinesis stream, otherwise nil.
+	KinesisRecordMetadata *KinesisRecordMetadata `json:"kinesisRecordMetadata,omitempty"`
 }
 
 // Input represents an Amazon Kinesis Firehose delivery stream event and
 // provides contextual information.
 type Input struct {
 	// The invocation ID.
-	InvocationID string
+	InvocationID string `json:"invocationId"`
 
 	// The ARN of the Amazon Kinesis Firehose.
-	DeliveryStreamARN string
+	DeliveryStreamARN string `json:"deliveryStreamArn"`
+
+	// The ARN of the Amazon Kinesis stream the delivery stream reads from.
+	// Provided when the delivery stream source is an Amazon Kinesis stream,
+	// otherwise empty.
+	SourceKinesisStreamARN string `json:"sourceKinesisStreamArn,omitempty"`
 
 	// The AWS region where the event originated.
-	Region string
+	Region string `json:"region"`
 
 	// The list of Amazon Kinesis Firehose event records.
-	Records []*InputRecord
+	Records []*InputRecord `json:"records"`
 }
 
 // String returns the string representation.
@@ -0,0 +1,69 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kinesisfirehoseevt
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// The limits Amazon Kinesis Firehose dynamic partitioning enforces on the
+// partition keys of a record.
+const (
+	// The maximum number of partition keys of a record.
+	MaxPartitionKeys = 50
+
+	// The maximum length, in bytes, of a partition key name.
+	MaxPartitionKeyNameLen = 64
+
+	// The maximum length, in bytes, of a partition key value.
+	MaxPartitionKeyValueLen = 1024
+)
+
+// partitionKeyName matches the valid partition key names.
+var partitionKeyName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// SetPartitionKey sets the partition key used by Amazon Kinesis Firehose
+// dynamic partitioning to deliver the record. The key is validated along with
+// the record by TransformRecords, or by Metadata.Validate.
+func (r *OutputRecord) SetPartitionKey(name, value string) {
+	if r.Metadata == nil {
+		r.Metadata = &Metadata{}
+	}
+	if r.Metadata.PartitionKeys == nil {
+		r.Metadata.PartitionKeys = make(map[string]string)
+	}
+	r.Metadata.PartitionKeys[name] = value
+}
+
+// Validate checks the partition keys against the limits of
+// Amazon Kinesis Firehose dynamic partitioning. Names must be made of
+// alphanumerics, hyphens and underscores, and values must not be empty.
+func (m *Metadata) Validate() error {
+	if len(m.PartitionKeys) > MaxPartitionKeys {
+		return fmt.Errorf("kinesisfirehoseevt: too many partition keys: %d", len(m.PartitionKeys))
+	}
+	for name, value := range m.PartitionKeys {
+		if len(name) > MaxPartitionKeyNameLen || !partitionKeyName.MatchString(name) {
+			return fmt.Errorf("kinesisfirehoseevt: invalid partition key name: %q", name)
+		}
+		if value == "" || len(value) > MaxPartitionKeyValueLen {
+			return fmt.Errorf("kinesisfirehoseevt: invalid partition key value length for %q: %d", name, len(value))
+		}
+	}
+	return nil
+}
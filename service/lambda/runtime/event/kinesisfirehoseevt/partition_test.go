@@ -0,0 +1,83 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kinesisfirehoseevt
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetPartitionKey(t *testing.T) {
+	rec := &OutputRecord{RecordID: "a", Result: ResultOk}
+	rec.SetPartitionKey("customerId", "1234")
+	rec.SetPartitionKey("year", "2020")
+	rec.SetPartitionKey("year", "2021")
+
+	want := map[string]string{"customerId": "1234", "year": "2021"}
+	if !reflect.DeepEqual(rec.Metadata.PartitionKeys, want) {
+		t.Errorf("got partition keys %v, want %v", rec.Metadata.PartitionKeys, want)
+	}
+
+	rec = &OutputRecord{Metadata: &Metadata{}}
+	rec.SetPartitionKey("year", "2021")
+	if want := map[string]string{"year": "2021"}; !reflect.DeepEqual(rec.Metadata.PartitionKeys, want) {
+		t.Errorf("got partition keys %v, want %v", rec.Metadata.PartitionKeys, want)
+	}
+}
+
+func TestMetadataValidate(t *testing.T) {
+	keys := func(n int) map[string]string {
+		m := make(map[string]string)
+		for i := 0; i < n; i++ {
+			m[fmt.Sprintf("key%d", i)] = "value"
+		}
+		return m
+	}
+
+	tests := []struct {
+		keys map[string]string
+		err  string
+	}{
+		{nil, ""},
+		{map[string]string{"customer_id": "1234", "Year-2": "2021"}, ""},
+		{keys(MaxPartitionKeys), ""},
+		{keys(MaxPartitionKeys + 1), "kinesisfirehoseevt: too many partition keys: 51"},
+		{map[string]string{strings.Repeat("k", MaxPartitionKeyNameLen): "v"}, ""},
+		{map[string]string{strings.Repeat("k", MaxPartitionKeyNameLen+1): "v"}, `kinesisfirehoseevt: invalid partition key name: "` + strings.Repeat("k", 65) + `"`},
+		{map[string]string{"": "v"}, `kinesisfirehoseevt: invalid partition key name: ""`},
+		{map[string]string{"customer.id": "v"}, `kinesisfirehoseevt: invalid partition key name: "customer.id"`},
+		{map[string]string{"année": "v"}, `kinesisfirehoseevt: invalid partition key name: "année"`},
+		{map[string]string{"k": strings.Repeat("v", MaxPartitionKeyValueLen)}, ""},
+		{map[string]string{"k": strings.Repeat("v", MaxPartitionKeyValueLen+1)}, `kinesisfirehoseevt: invalid partition key value length for "k": 1025`},
+		{map[string]string{"k": ""}, `kinesisfirehoseevt: invalid partition key value length for "k": 0`},
+	}
+
+	for _, tt := range tests {
+		err := (&Metadata{PartitionKeys: tt.keys}).Validate()
+		if tt.err == "" {
+			if err != nil {
+				t.Errorf("%d keys: %v", len(tt.keys), err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tt.err {
+			t.Errorf("got %v, want %s", err, tt.err)
+		}
+	}
+}
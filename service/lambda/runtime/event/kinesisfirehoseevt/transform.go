@@ -0,0 +1,135 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kinesisfirehoseevt
+
+import "encoding/json"
+
+// MaxResponseSize is the maximum size, in bytes, of the response of an
+// AWS Lambda function.
+const MaxResponseSize = 6 * 1024 * 1024
+
+// TransformFunc transforms the data of a single input record. It returns the
+// transformed data and the status of the transformation. An empty status is
+// read as ResultOk.
+type TransformFunc func(rec *InputRecord) ([]byte, Result, error)
+
+// RecordTransformFunc transforms a single input record by filling the output
+// record, which comes with the record ID set and a ResultOk status. It is
+// given the output record to set the partition keys used by
+// Amazon Kinesis Firehose dynamic partitioning.
+type RecordTransformFunc func(in *InputRecord, out *OutputRecord) error
+
+// Transform runs the transformation for each record of the input and returns
+// an output holding exactly one record per input record, in the same order.
+// See TransformRecords for the handling of failures.
+func Transform(in *Input, fn TransformFunc) *Output {
+	return TransformRecords(in, func(rec *InputRecord, orec *OutputRecord) error {
+		data, res, err := fn(rec)
+		if err != nil {
+			return err
+		}
+		if res != "" {
+			orec.Result = res
+		}
+		orec.Data = data
+		return nil
+	})
+}
+
+// TransformRecords runs the transformation for each record of the input and
+// returns an output holding exactly one record per input record, in the same
+// order.
+//
+// Records whose transformation fails, panics, sets an unknown status or
+// invalid partition keys are marked as ResultProcessingFailed and keep their
+// original data. Records which would make the output exceed MaxResponseSize
+// are marked as ResultProcessingFailed as well, without data, so that
+// Amazon Kinesis Firehose delivers them to the error output instead of failing
+// the whole batch. Room is kept for the records still to be transformed, so
+// that they can always be marked that way.
+func TransformRecords(in *Input, fn RecordTransformFunc) *Output {
+	out := &Output{Records: make([]*OutputRecord, 0, len(in.Records))}
+
+	// The size of each record once marked as failed without data, followed
+	// by the comma separating it from the next one, and the total size of the
+	// records still to be transformed.
+	oversized := make([]int, len(in.Records))
+	reserved := 0
+	for i, rec := range in.Records {
+		oversized[i] = recordSize(oversizedRecord(rec)) + 1
+		reserved += oversized[i]
+	}
+
+	// The size of the enclosing {"records":[]} object, minus the comma the
+	// last record is not followed by.
+	size := len(`{"records":[]}`) - 1
+	for i, rec := range in.Records {
+		reserved -= oversized[i]
+
+		orec := transformRecord(rec, fn)
+		n := recordSize(orec) + 1
+		if size+n+reserved > MaxResponseSize {
+			orec = oversizedRecord(rec)
+			n = oversized[i]
+		}
+		size += n
+
+		out.Records = append(out.Records, orec)
+	}
+	return out
+}
+
+func transformRecord(rec *InputRecord, fn RecordTransformFunc) (orec *OutputRecord) {
+	defer func() {
+		if v := recover(); v != nil {
+			orec = failedRecord(rec)
+		}
+	}()
+
+	orec = &OutputRecord{RecordID: rec.RecordID, Result: ResultOk}
+	if err := fn(rec, orec); err != nil {
+		return failedRecord(rec)
+	}
+	switch orec.Result {
+	case ResultOk, ResultDropped, ResultProcessingFailed:
+	default:
+		return failedRecord(rec)
+	}
+	if orec.Metadata != nil {
+		if err := orec.Metadata.Validate(); err != nil {
+			return failedRecord(rec)
+		}
+	}
+	// The record ID cannot be changed by the transformation.
+	orec.RecordID = rec.RecordID
+	return orec
+}
+
+func failedRecord(rec *InputRecord) *OutputRecord {
+	return &OutputRecord{RecordID: rec.RecordID, Result: ResultProcessingFailed, Data: rec.Data}
+}
+
+// oversizedRecord returns the record marked as failed, without data.
+func oversizedRecord(rec *InputRecord) *OutputRecord {
+	return &OutputRecord{RecordID: rec.RecordID, Result: ResultProcessingFailed}
+}
+
+// recordSize returns the size of the JSON encoding of the record.
+func recordSize(rec *OutputRecord) int {
+	b, _ := json.Marshal(rec)
+	return len(b)
+}
@@ -0,0 +1,170 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kinesisfirehoseevt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newTestInput(data ...string) *Input {
+	in := &Input{}
+	for i, d := range data {
+		in.Records = append(in.Records, &InputRecord{RecordID: string('a' + rune(i)), Data: []byte(d)})
+	}
+	return in
+}
+
+func TestTransform(t *testing.T) {
+	in := newTestInput("ok", "default", "drop", "fail", "error", "panic", "invalid")
+	out := Transform(in, func(rec *InputRecord) ([]byte, Result, error) {
+		data := bytes.ToUpper(rec.Data)
+		switch string(rec.Data) {
+		case "ok":
+			return data, ResultOk, nil
+		case "default":
+			return data, "", nil
+		case "drop":
+			return nil, ResultDropped, nil
+		case "fail":
+			return data, ResultProcessingFailed, nil
+		case "error":
+			return data, ResultOk, errors.New("failed")
+		case "panic":
+			panic("boom")
+		}
+		return data, "Retry", nil
+	})
+
+	want := []*OutputRecord{
+		{RecordID: "a", Result: ResultOk, Data: []byte("OK")},
+		{RecordID: "b", Result: ResultOk, Data: []byte("DEFAULT")},
+		{RecordID: "c", Result: ResultDropped},
+		{RecordID: "d", Result: ResultProcessingFailed, Data: []byte("FAIL")},
+		{RecordID: "e", Result: ResultProcessingFailed, Data: []byte("error")},
+		{RecordID: "f", Result: ResultProcessingFailed, Data: []byte("panic")},
+		{RecordID: "g", Result: ResultProcessingFailed, Data: []byte("invalid")},
+	}
+	if !reflect.DeepEqual(out.Records, want) {
+		t.Errorf("got %v, want %v", out, &Output{Records: want})
+	}
+}
+
+func TestTransformEmpty(t *testing.T) {
+	out := Transform(&Input{}, func(rec *InputRecord) ([]byte, Result, error) {
+		t.Error("unexpected call")
+		return nil, "", nil
+	})
+	if s := out.String(); s != `{"records":[]}` {
+		t.Errorf("unexpected output: %s", s)
+	}
+}
+
+func TestTransformMaxResponseSize(t *testing.T) {
+	in := newTestInput("1", "2", "3", "4")
+	out := Transform(in, func(rec *InputRecord) ([]byte, Result, error) {
+		if string(rec.Data) == "4" {
+			return rec.Data, ResultOk, nil
+		}
+		return make([]byte, 2*1024*1024), ResultOk, nil
+	})
+
+	results := []Result{}
+	for _, rec := range out.Records {
+		results = append(results, rec.Result)
+	}
+	want := []Result{ResultOk, ResultOk, ResultProcessingFailed, ResultOk}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("got results %v, want %v", results, want)
+	}
+	if out.Records[2].Data != nil || out.Records[2].RecordID != "c" {
+		t.Errorf("got %v for the oversized record", out.Records[2])
+	}
+	if n := outputSize(t, out); n > MaxResponseSize {
+		t.Errorf("got an output of %d bytes", n)
+	}
+}
+
+func TestTransformMaxResponseSizeReserved(t *testing.T) {
+	// The size of the output when the first record holds no data, the second
+	// one being marked as failed, without data. Each 3 bytes of data of the
+	// first record add 4 bytes to the output.
+	base := outputSize(t, &Output{Records: []*OutputRecord{
+		{RecordID: "a", Result: ResultOk, Data: []byte{}},
+		{RecordID: "b", Result: ResultProcessingFailed},
+	}})
+	limit := (MaxResponseSize - base) / 4 * 3
+
+	for n := limit - 6; n <= limit+6; n++ {
+		out := Transform(newTestInput("1", "2"), func(rec *InputRecord) ([]byte, Result, error) {
+			if string(rec.Data) == "1" {
+				return make([]byte, n), ResultOk, nil
+			}
+			return bytes.Repeat([]byte("x"), 100), ResultOk, nil
+		})
+
+		if len(out.Records) != 2 {
+			t.Fatalf("%d: got %d records", n, len(out.Records))
+		}
+		if size := outputSize(t, out); size > MaxResponseSize {
+			t.Errorf("%d: got an output of %d bytes", n, size)
+		}
+		want := []Result{ResultOk, ResultProcessingFailed}
+		if n > limit {
+			want = []Result{ResultProcessingFailed, ResultOk}
+		}
+		if got := []Result{out.Records[0].Result, out.Records[1].Result}; !reflect.DeepEqual(got, want) {
+			t.Errorf("%d: got results %v, want %v", n, got, want)
+		}
+	}
+}
+
+func outputSize(t *testing.T, out *Output) int {
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(b)
+}
+
+func TestTransformRecords(t *testing.T) {
+	in := newTestInput("valid", "invalid", "id")
+	out := TransformRecords(in, func(rec *InputRecord, orec *OutputRecord) error {
+		orec.Data = bytes.ToUpper(rec.Data)
+		switch string(rec.Data) {
+		case "valid":
+			orec.SetPartitionKey("customerId", "1234")
+		case "invalid":
+			orec.SetPartitionKey("customer id", "1234")
+		case "id":
+			orec.RecordID = "z"
+		}
+		return nil
+	})
+
+	want := []*OutputRecord{
+		{RecordID: "a", Result: ResultOk, Data: []byte("VALID"), Metadata: &Metadata{PartitionKeys: map[string]string{"customerId": "1234"}}},
+		{RecordID: "b", Result: ResultProcessingFailed, Data: []byte("invalid")},
+		{RecordID: "c", Result: ResultOk, Data: []byte("ID")},
+	}
+	if !reflect.DeepEqual(out.Records, want) {
+		t.Errorf("got %v, want %v", out, &Output{Records: want})
+	}
+}
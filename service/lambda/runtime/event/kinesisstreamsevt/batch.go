@@ -0,0 +1,80 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kinesisstreamsevt
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/internal/batch"
+)
+
+// BatchItemFailure identifies a record which failed to be processed.
+type BatchItemFailure struct {
+	// The sequence number of the record.
+	ItemIdentifier string `json:"itemIdentifier"`
+}
+
+// BatchResponse represents the partial batch response of an AWS Lambda function
+// processing Amazon Kinesis Streams events. The function must be configured to
+// report batch item failures, in which case only the failed records, and the
+// ones after them, are retried.
+type BatchResponse struct {
+	// The list of records which failed to be processed.
+	BatchItemFailures []BatchItemFailure `json:"batchItemFailures"`
+}
+
+// String returns the string representation.
+func (r *BatchResponse) String() string {
+	s, _ := json.Marshal(r)
+	return string(s)
+}
+
+// GoString returns the string representation.
+func (r *BatchResponse) GoString() string {
+	return r.String()
+}
+
+// RecordHandlerFunc processes a single event record.
+type RecordHandlerFunc func(ctx context.Context, rec *EventRecord) error
+
+// Process runs the handler for each record of the event, in order, and returns
+// the partial batch response to send back to AWS Lambda.
+// To preserve ordering, Process stops at the first record whose handler fails
+// or panics, or once ctx is done, and reports that record as failed.
+// A record without Amazon Kinesis data is reported with an empty identifier,
+// which AWS Lambda handles as the failure of the whole batch.
+func (e *Event) Process(ctx context.Context, h RecordHandlerFunc) *BatchResponse {
+	resp := &BatchResponse{BatchItemFailures: []BatchItemFailure{}}
+	failed := batch.Process(ctx, len(e.Records), batch.Ordered, func(ctx context.Context, i int) error {
+		return h(ctx, e.Records[i])
+	})
+	if len(failed) > 0 {
+		rec := e.Records[failed[0]]
+		resp.BatchItemFailures = append(resp.BatchItemFailures, BatchItemFailure{sequenceNumber(rec)})
+	}
+	return resp
+}
+
+// sequenceNumber returns the sequence number of the record, or an empty string
+// if the record carries no Amazon Kinesis data.
+func sequenceNumber(rec *EventRecord) string {
+	if rec == nil || rec.Kinesis == nil {
+		return ""
+	}
+	return rec.Kinesis.SequenceNumber
+}
@@ -0,0 +1,256 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kinesisstreamsevt
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+)
+
+// The Kinesis Producer Library (KPL) aggregated record format is made of the
+// magic prefix, followed by a protobuf encoded AggregatedRecord message,
+// followed by the MD5 checksum of the message.
+// See https://github.com/awslabs/amazon-kinesis-producer/blob/master/aggregation-format.md
+var aggregatedMagic = []byte{0xF3, 0x89, 0x9A, 0xC2}
+
+var (
+	// ErrChecksumMismatch is returned when the MD5 checksum of an aggregated
+	// record does not match its content.
+	ErrChecksumMismatch = errors.New("kinesisstreamsevt: aggregated record checksum mismatch")
+
+	// ErrMalformedRecord is returned when an aggregated record cannot be
+	// decoded.
+	ErrMalformedRecord = errors.New("kinesisstreamsevt: malformed aggregated record")
+)
+
+// UserRecord represents a record as put by a producer. It is either one of the
+// sub-records of a Kinesis Producer Library (KPL) aggregated record or the
+// Amazon Kinesis Streams record itself.
+type UserRecord struct {
+	// The event record the user record has been extracted from.
+	EventRecord *EventRecord
+
+	// The position of the user record within the aggregated record. Combined
+	// with the sequence number of the underlying Amazon Kinesis Streams
+	// record, it uniquely identifies the user record.
+	// The value is always 0 for a record which is not aggregated.
+	SubSequenceNumber int64
+
+	// The partition key of the user record.
+	PartitionKey string
+
+	// The explicit hash key of the user record, if any.
+	ExplicitHashKey string
+
+	// The data blob of the user record.
+	Data []byte
+
+	// Aggregated reports whether the user record has been extracted from a
+	// KPL aggregated record.
+	Aggregated bool
+}
+
+// Deaggregate expands all event records into their user records.
+// See EventRecord.Deaggregate.
+func (e *Event) Deaggregate() ([]*UserRecord, error) {
+	var urs []*UserRecord
+	for _, r := range e.Records {
+		rs, err := r.Deaggregate()
+		if err != nil {
+			return nil, err
+		}
+		urs = append(urs, rs...)
+	}
+	return urs, nil
+}
+
+// Deaggregate expands the event record into the user records of the
+// Kinesis Producer Library (KPL) aggregated record it holds. The MD5 checksum
+// of the aggregated record is verified. If the event record does not hold an
+// aggregated record, Deaggregate returns a single user record built from the
+// event record itself.
+func (e *EventRecord) Deaggregate() ([]*UserRecord, error) {
+	if e.Kinesis == nil {
+		return nil, nil
+	}
+
+	data := e.Kinesis.Data
+	if len(data) < len(aggregatedMagic)+md5.Size || !bytes.HasPrefix(data, aggregatedMagic) {
+		return []*UserRecord{{
+			EventRecord:  e,
+			PartitionKey: e.Kinesis.PartitionKey,
+			Data:         data,
+		}}, nil
+	}
+
+	msg := data[len(aggregatedMagic) : len(data)-md5.Size]
+	sum := md5.Sum(msg)
+	if !bytes.Equal(sum[:], data[len(data)-md5.Size:]) {
+		return nil, ErrChecksumMismatch
+	}
+
+	agg, err := decodeAggregatedRecord(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	urs := make([]*UserRecord, len(agg.records))
+	for i, r := range agg.records {
+		if r.partitionKeyIndex >= uint64(len(agg.partitionKeys)) {
+			return nil, ErrMalformedRecord
+		}
+		ur := &UserRecord{
+			EventRecord:       e,
+			SubSequenceNumber: int64(i),
+			PartitionKey:      agg.partitionKeys[r.partitionKeyIndex],
+			Data:              r.data,
+			Aggregated:        true,
+		}
+		if r.hasExplicitHashKey {
+			if r.explicitHashKeyIndex >= uint64(len(agg.explicitHashKeys)) {
+				return nil, ErrMalformedRecord
+			}
+			ur.ExplicitHashKey = agg.explicitHashKeys[r.explicitHashKeyIndex]
+		}
+		urs[i] = ur
+	}
+
+	return urs, nil
+}
+
+type aggregatedRecord struct {
+	partitionKeys    []string
+	explicitHashKeys []string
+	records          []*subRecord
+}
+
+type subRecord struct {
+	partitionKeyIndex    uint64
+	explicitHashKeyIndex uint64
+	hasExplicitHashKey   bool
+	data                 []byte
+}
+
+// decodeAggregatedRecord decodes the protobuf message:
+//
+//	message AggregatedRecord {
+//		repeated string partition_key_table     = 1;
+//		repeated string explicit_hash_key_table = 2;
+//		repeated Record records                 = 3;
+//	}
+func decodeAggregatedRecord(b []byte) (*aggregatedRecord, error) {
+	agg := &aggregatedRecord{}
+	err := decodeMessage(b, func(num int, wt int, v uint64, p []byte) error {
+		switch {
+		case num == 1 && wt == wireBytes:
+			agg.partitionKeys = append(agg.partitionKeys, string(p))
+		case num == 2 && wt == wireBytes:
+			agg.explicitHashKeys = append(agg.explicitHashKeys, string(p))
+		case num == 3 && wt == wireBytes:
+			r, err := decodeSubRecord(p)
+			if err != nil {
+				return err
+			}
+			agg.records = append(agg.records, r)
+		}
+		return nil
+	})
+	return agg, err
+}
+
+// decodeSubRecord decodes the protobuf message:
+//
+//	message Record {
+//		required uint64 partition_key_index     = 1;
+//		optional uint64 explicit_hash_key_index = 2;
+//		required bytes  data                    = 3;
+//		repeated Tag    tags                    = 4;
+//	}
+func decodeSubRecord(b []byte) (*subRecord, error) {
+	r := &subRecord{}
+	err := decodeMessage(b, func(num int, wt int, v uint64, p []byte) error {
+		switch {
+		case num == 1 && wt == wireVarint:
+			r.partitionKeyIndex = v
+		case num == 2 && wt == wireVarint:
+			r.explicitHashKeyIndex = v
+			r.hasExplicitHashKey = true
+		case num == 3 && wt == wireBytes:
+			r.data = p
+		}
+		return nil
+	})
+	return r, err
+}
+
+// The protobuf wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// decodeMessage walks through the fields of the protobuf message b and calls
+// fn for each of them with either the varint value v or the length-delimited
+// payload p, depending on the wire type wt.
+func decodeMessage(b []byte, fn func(num int, wt int, v uint64, p []byte) error) error {
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return ErrMalformedRecord
+		}
+		b = b[n:]
+
+		num, wt := int(key>>3), int(key&7)
+		var v uint64
+		var p []byte
+		switch wt {
+		case wireVarint:
+			v, n = binary.Uvarint(b)
+			if n <= 0 {
+				return ErrMalformedRecord
+			}
+			b = b[n:]
+		case wireFixed64:
+			if len(b) < 8 {
+				return ErrMalformedRecord
+			}
+			v, b = binary.LittleEndian.Uint64(b), b[8:]
+		case wireFixed32:
+			if len(b) < 4 {
+				return ErrMalformedRecord
+			}
+			v, b = uint64(binary.LittleEndian.Uint32(b)), b[4:]
+		case wireBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || l > uint64(len(b)-n) {
+				return ErrMalformedRecord
+			}
+			p, b = b[n:n+int(l)], b[n+int(l):]
+		default:
+			return ErrMalformedRecord
+		}
+
+		if err := fn(num, wt, v, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
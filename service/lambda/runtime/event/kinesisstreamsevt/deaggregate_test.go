@@ -0,0 +1,146 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kinesisstreamsevt
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+func loadEvent(t *testing.T, file string) *Event {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var e Event
+	if err := json.Unmarshal(data, &e); err != nil {
+		t.Fatal(err)
+	}
+	return &e
+}
+
+func TestDeaggregate(t *testing.T) {
+	e := loadEvent(t, "testdata/event-aggregated.json")
+
+	urs, err := e.Deaggregate()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	agg, plain := e.Records[0], e.Records[1]
+	want := []*UserRecord{
+		{EventRecord: agg, SubSequenceNumber: 0, PartitionKey: "pk-a", Data: []byte("hello"), Aggregated: true},
+		{EventRecord: agg, SubSequenceNumber: 1, PartitionKey: "pk-b", ExplicitHashKey: "170141183460469231731687303715884105728", Data: []byte("world"), Aggregated: true},
+		{EventRecord: agg, SubSequenceNumber: 2, PartitionKey: "pk-a", Data: []byte(`{"id":3}`), Aggregated: true},
+		{EventRecord: plain, PartitionKey: "pk-c", Data: []byte("plain")},
+	}
+	if len(urs) != len(want) {
+		t.Fatalf("got %d user records, want %d", len(urs), len(want))
+	}
+	for i := range want {
+		if !reflect.DeepEqual(urs[i], want[i]) {
+			t.Errorf("user record %d: got %+v, want %+v", i, urs[i], want[i])
+		}
+	}
+}
+
+func TestDeaggregateNotAggregated(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", []byte{}},
+		{"plain", []byte("Hello, this is a test 123.")},
+		{"magic only", aggregatedMagic},
+		{"magic and short tail", append(append([]byte{}, aggregatedMagic...), make([]byte, md5.Size-1)...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &EventRecord{Kinesis: &Record{PartitionKey: "pk", Data: tt.data}}
+			urs, err := r.Deaggregate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []*UserRecord{{EventRecord: r, PartitionKey: "pk", Data: tt.data}}
+			if !reflect.DeepEqual(urs, want) {
+				t.Errorf("got %+v, want %+v", urs, want)
+			}
+		})
+	}
+
+	if urs, err := (&EventRecord{}).Deaggregate(); urs != nil || err != nil {
+		t.Errorf("got %v, %v for a record without data", urs, err)
+	}
+}
+
+// aggregate wraps the protobuf message msg into a KPL aggregated record.
+func aggregate(msg []byte) []byte {
+	sum := md5.Sum(msg)
+	b := append([]byte{}, aggregatedMagic...)
+	b = append(b, msg...)
+	return append(b, sum[:]...)
+}
+
+func TestDeaggregateMalformed(t *testing.T) {
+	fixture := loadEvent(t, "testdata/event-aggregated.json").Records[0].Kinesis.Data
+	corrupted := append([]byte{}, fixture...)
+	corrupted[len(aggregatedMagic)+3] ^= 0xFF
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"checksum mismatch", corrupted, ErrChecksumMismatch},
+		{"partition key index out of range", aggregate([]byte{
+			0x0a, 0x01, 'k', // partition_key_table: "k"
+			0x1a, 0x05, 0x08, 0x01, 0x1a, 0x01, 'x', // records: {partition_key_index: 1, data: "x"}
+		}), ErrMalformedRecord},
+		{"explicit hash key index out of range", aggregate([]byte{
+			0x0a, 0x01, 'k', // partition_key_table: "k"
+			0x1a, 0x07, 0x08, 0x00, 0x10, 0x00, 0x1a, 0x01, 'x', // records: {partition_key_index: 0, explicit_hash_key_index: 0, data: "x"}
+		}), ErrMalformedRecord},
+		{"truncated key", aggregate([]byte{0x80}), ErrMalformedRecord},
+		{"truncated varint", aggregate([]byte{
+			0x0a, 0x01, 'k', // partition_key_table: "k"
+			0x1a, 0x02, 0x08, 0x80, // records: {partition_key_index: <truncated>}
+		}), ErrMalformedRecord},
+		{"truncated length", aggregate([]byte{0x0a, 0x80}), ErrMalformedRecord},
+		{"length past the end", aggregate([]byte{0x0a, 0x05, 'k'}), ErrMalformedRecord},
+		{"truncated fixed64", aggregate([]byte{0x09, 0x00, 0x00}), ErrMalformedRecord},
+		{"truncated fixed32", aggregate([]byte{0x0d, 0x00}), ErrMalformedRecord},
+		{"unsupported wire type", aggregate([]byte{0x0b}), ErrMalformedRecord},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &EventRecord{Kinesis: &Record{Data: tt.data}}
+			if _, err := r.Deaggregate(); err != tt.want {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+
+			e := &Event{Records: []*EventRecord{{Kinesis: &Record{Data: []byte("ok")}}, r}}
+			if urs, err := e.Deaggregate(); urs != nil || err != tt.want {
+				t.Errorf("got %v, %v from Event, want %v", urs, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package kinesisstreamsevt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		data string
+		want time.Time
+		enc  string
+	}{
+		{"1428537600", time.Unix(1428537600, 0), "1428537600"},
+		{"1428537600.5", time.Unix(1428537600, 5e8), "1428537600.5"},
+		{"1428537600.123", time.Unix(1428537600, 123e6), "1428537600.123"},
+		{"1428537600.1234567891", time.Unix(1428537600, 123456789), "1428537600.123456789"},
+		{"0.001", time.Unix(0, 1e6), "0.001"},
+		{"-2", time.Unix(-2, 0), "-2"},
+		{"-1.5", time.Unix(-1, -5e8), "-1.5"},
+		{"-0.25", time.Unix(0, -25e7), "-0.25"},
+		{"1.5e3", time.Unix(1500, 0), "1500"},
+	}
+
+	for _, tt := range tests {
+		var ts timestamp
+		if err := json.Unmarshal([]byte(tt.data), &ts); err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if !ts.Equal(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.data, ts.Time, tt.want)
+		}
+
+		enc, err := json.Marshal(ts)
+		if err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if string(enc) != tt.enc {
+			t.Errorf("%s: got %s, want %s", tt.data, enc, tt.enc)
+		}
+	}
+}
+
+func TestTimestampInvalid(t *testing.T) {
+	for _, data := range []string{`"1428537600"`, `null`, `1.2.3`, `true`} {
+		var ts timestamp
+		if err := json.Unmarshal([]byte(data), &ts); err == nil {
+			t.Errorf("%s: expected error", data)
+		}
+	}
+}
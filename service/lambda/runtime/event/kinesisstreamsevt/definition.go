@@ -24,11 +24,11 @@ import (
 // Record represents the unit of data of an Amazon Kinesis Stream.
 type Record struct {
 	// The schema version for the record.
-	KinesisSchemaVersion string
+	KinesisSchemaVersion string `json:"kinesisSchemaVersion"`
 
 	// The unique identifier of the record in the stream.
 	// See also http://docs.aws.amazon.com/streams/latest/dev/kinesis-record-processor-duplicates.html
-	SequenceNumber string
+	SequenceNumber string `json:"sequenceNumber"`
 
 	// The approximate time that the record was inserted into the stream.
 	// This is set when a stream successfully receives and stores a record
@@ -36,10 +36,10 @@ type Record struct {
 	// millisecond precision and there are no guarantees about its accuracy,
 	// or that the it is always increasing. For example, records in a shard
 	// or across a stream might have timestamps that are out of order.
-	ApproximateArrivalTimestamp time.Time
+	ApproximateArrivalTimestamp time.Time `json:"-"`
 
 	// Identifies which shard in the stream the data record is assigned to.
-	PartitionKey string
+	PartitionKey string `json:"partitionKey"`
 
 	// The data blob. The data in the blob is both opaque and immutable to
 	// the Amazon Kinesis service, which does not inspect, interpret, or
@@ -47,36 +47,36 @@ type Record struct {
 	// payload before base64-encoding) is added to the partition key size,
 	// the total size must not exceed the maximum record size (1 MB).
 	// Data is automatically base64 encoded/decoded by the SDK.
-	Data []byte
+	Data []byte `json:"data"`
 }
 
 // EventRecord provides contextual information about an Amazon Kinesis Streams
 // event.
 type EventRecord struct {
 	// The event id.
-	EventID string
+	EventID string `json:"eventID"`
 
 	// The name of the event.
-	EventName string
+	EventName string `json:"eventName"`
 
 	// The event version.
-	EventVersion string
+	EventVersion string `json:"eventVersion"`
 
 	// The source of the event.
-	EventSource string
+	EventSource string `json:"eventSource"`
 
 	// The ARN of the event source.
-	EventSourceARN string
+	EventSourceARN string `json:"eventSourceARN"`
 
 	// The ARN for the identity used to invoke the Lambda Function.
-	InvokeIdentityARN string
+	InvokeIdentityARN string `json:"invokeIdentityArn"`
 
 	// The AWS region where the event originated.
-	AWSRegion string
+	AWSRegion string `json:"awsRegion"`
 
 	// The underlying Amazon Kinesis Streams record associated with the
 	// event.
-	Kinesis *Record
+	Kinesis *Record `json:"kinesis"`
 }
 
 // String returns the string representation.
@@ -93,7 +93,7 @@ func (e *EventRecord) GoString() string {
 // Event represents an Amazon Kinesis Streams event.
 type Event struct {
 	// The list of Amazon Kinesis Streams event records.
-	Records []*EventRecord
+	Records []*EventRecord `json:"Records"`
 }
 
 // String returns the string representation.
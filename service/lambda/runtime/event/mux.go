@@ -0,0 +1,185 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/apigatewayauthorizerevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/apigatewayproxyevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/cloudformationevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/cloudwatchlogsevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/cloudwatchschedevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/codepipelineevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/cognitosyncevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/dynamodbstreamsevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/eventbridgeevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/kinesisfirehoseevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/kinesisstreamsevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/s3evt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/sesevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/snsevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/sqsevt"
+)
+
+// ErrUnknownSource is returned by Mux.Handle when the source of the event
+// cannot be detected and no default handler has been registered.
+var ErrUnknownSource = errors.New("event: unknown event source")
+
+// HandlerFunc handles a raw JSON event.
+type HandlerFunc func(data json.RawMessage) (interface{}, error)
+
+// Mux routes raw JSON events to the handler registered for their source, as
+// identified by Detect. The event is decoded into the type of its source
+// before being handed to the handler.
+type Mux struct {
+	handlers map[Source]HandlerFunc
+	fallback HandlerFunc
+}
+
+// NewMux returns a new Mux.
+func NewMux() *Mux {
+	return &Mux{handlers: make(map[Source]HandlerFunc)}
+}
+
+// Handle detects the source of the event and dispatches it to the matching
+// handler. The default handler, if any, receives the events of unknown
+// sources or of sources without handler.
+func (m *Mux) Handle(data json.RawMessage) (interface{}, error) {
+	src := Detect(data)
+	if h, ok := m.handlers[src]; ok {
+		return h(data)
+	}
+	if m.fallback != nil {
+		return m.fallback(data)
+	}
+	if src == Unknown {
+		return nil, ErrUnknownSource
+	}
+	return nil, fmt.Errorf("event: no handler for event source: %s", src)
+}
+
+// HandleFunc registers the raw JSON handler for the given source.
+func (m *Mux) HandleFunc(src Source, h HandlerFunc) {
+	m.handlers[src] = h
+}
+
+// HandleDefault registers the raw JSON handler for the events of unknown
+// sources or of sources without handler.
+func (m *Mux) HandleDefault(h HandlerFunc) {
+	m.fallback = h
+}
+
+// handle registers for src the typed handler h, a func(*T) (interface{},
+// error). The event is decoded into a new T before being handed to h.
+func (m *Mux) handle(src Source, h interface{}) {
+	hv := reflect.ValueOf(h)
+	t := hv.Type().In(0).Elem()
+	m.HandleFunc(src, func(data json.RawMessage) (interface{}, error) {
+		evt := reflect.New(t)
+		if err := json.Unmarshal(data, evt.Interface()); err != nil {
+			return nil, err
+		}
+		out := hv.Call([]reflect.Value{evt})
+		err, _ := out[1].Interface().(error)
+		return out[0].Interface(), err
+	})
+}
+
+// HandleAPIGatewayProxy registers the handler for Amazon API Gateway Proxy
+// events.
+func (m *Mux) HandleAPIGatewayProxy(h func(*apigatewayproxyevt.Event) (interface{}, error)) {
+	m.handle(APIGatewayProxy, h)
+}
+
+// HandleAPIGatewayAuthorizer registers the handler for Amazon API Gateway
+// Custom Authorizer events.
+func (m *Mux) HandleAPIGatewayAuthorizer(h func(*apigatewayauthorizerevt.Event) (interface{}, error)) {
+	m.handle(APIGatewayAuthorizer, h)
+}
+
+// HandleCloudFormation registers the handler for AWS CloudFormation events.
+func (m *Mux) HandleCloudFormation(h func(*cloudformationevt.Event) (interface{}, error)) {
+	m.handle(CloudFormation, h)
+}
+
+// HandleCloudWatchLogs registers the handler for Amazon CloudWatch Logs events.
+func (m *Mux) HandleCloudWatchLogs(h func(*cloudwatchlogsevt.Event) (interface{}, error)) {
+	m.handle(CloudWatchLogs, h)
+}
+
+// HandleCloudWatchScheduled registers the handler for Amazon CloudWatch
+// Scheduled events.
+func (m *Mux) HandleCloudWatchScheduled(h func(*cloudwatchschedevt.Event) (interface{}, error)) {
+	m.handle(CloudWatchScheduled, h)
+}
+
+// HandleCodePipeline registers the handler for AWS CodePipeline events.
+func (m *Mux) HandleCodePipeline(h func(*codepipelineevt.Event) (interface{}, error)) {
+	m.handle(CodePipeline, h)
+}
+
+// HandleCognitoSync registers the handler for Amazon Cognito Sync events.
+func (m *Mux) HandleCognitoSync(h func(*cognitosyncevt.Event) (interface{}, error)) {
+	m.handle(CognitoSync, h)
+}
+
+// HandleDynamoDBStreams registers the handler for Amazon DynamoDB Streams
+// events.
+func (m *Mux) HandleDynamoDBStreams(h func(*dynamodbstreamsevt.Event) (interface{}, error)) {
+	m.handle(DynamoDBStreams, h)
+}
+
+// HandleEventBridge registers the handler for Amazon EventBridge events, other
+// than the scheduled ones.
+func (m *Mux) HandleEventBridge(h func(*eventbridgeevt.Event) (interface{}, error)) {
+	m.handle(EventBridge, h)
+}
+
+// HandleKinesisFirehose registers the handler for Amazon Kinesis Firehose
+// events.
+func (m *Mux) HandleKinesisFirehose(h func(*kinesisfirehoseevt.Input) (interface{}, error)) {
+	m.handle(KinesisFirehose, h)
+}
+
+// HandleKinesisStreams registers the handler for Amazon Kinesis Streams events.
+func (m *Mux) HandleKinesisStreams(h func(*kinesisstreamsevt.Event) (interface{}, error)) {
+	m.handle(KinesisStreams, h)
+}
+
+// HandleS3 registers the handler for Amazon S3 events.
+func (m *Mux) HandleS3(h func(*s3evt.Event) (interface{}, error)) {
+	m.handle(S3, h)
+}
+
+// HandleSES registers the handler for Amazon SES events.
+func (m *Mux) HandleSES(h func(*sesevt.Event) (interface{}, error)) {
+	m.handle(SES, h)
+}
+
+// HandleSNS registers the handler for Amazon SNS events.
+func (m *Mux) HandleSNS(h func(*snsevt.Event) (interface{}, error)) {
+	m.handle(SNS, h)
+}
+
+// HandleSQS registers the handler for Amazon SQS events.
+func (m *Mux) HandleSQS(h func(*sqsevt.Event) (interface{}, error)) {
+	m.handle(SQS, h)
+}
@@ -0,0 +1,197 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/apigatewayauthorizerevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/apigatewayproxyevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/cloudformationevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/cloudwatchlogsevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/cloudwatchschedevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/codepipelineevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/cognitosyncevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/dynamodbstreamsevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/eventbridgeevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/kinesisfirehoseevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/kinesisstreamsevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/s3evt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/sesevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/snsevt"
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/sqsevt"
+)
+
+func readFile(t *testing.T, file string) json.RawMessage {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestMuxHandle(t *testing.T) {
+	m := NewMux()
+	m.HandleAPIGatewayProxy(func(evt *apigatewayproxyevt.Event) (interface{}, error) { return evt, nil })
+	m.HandleAPIGatewayAuthorizer(func(evt *apigatewayauthorizerevt.Event) (interface{}, error) { return evt, nil })
+	m.HandleCloudFormation(func(evt *cloudformationevt.Event) (interface{}, error) { return evt, nil })
+	m.HandleCloudWatchLogs(func(evt *cloudwatchlogsevt.Event) (interface{}, error) { return evt, nil })
+	m.HandleCloudWatchScheduled(func(evt *cloudwatchschedevt.Event) (interface{}, error) { return evt, nil })
+	m.HandleCodePipeline(func(evt *codepipelineevt.Event) (interface{}, error) { return evt, nil })
+	m.HandleCognitoSync(func(evt *cognitosyncevt.Event) (interface{}, error) { return evt, nil })
+	m.HandleDynamoDBStreams(func(evt *dynamodbstreamsevt.Event) (interface{}, error) { return evt, nil })
+	m.HandleEventBridge(func(evt *eventbridgeevt.Event) (interface{}, error) { return evt, nil })
+	m.HandleKinesisFirehose(func(evt *kinesisfirehoseevt.Input) (interface{}, error) { return evt, nil })
+	m.HandleKinesisStreams(func(evt *kinesisstreamsevt.Event) (interface{}, error) { return evt, nil })
+	m.HandleS3(func(evt *s3evt.Event) (interface{}, error) { return evt, nil })
+	m.HandleSES(func(evt *sesevt.Event) (interface{}, error) { return evt, nil })
+	m.HandleSNS(func(evt *snsevt.Event) (interface{}, error) { return evt, nil })
+	m.HandleSQS(func(evt *sqsevt.Event) (interface{}, error) { return evt, nil })
+
+	tests := []struct {
+		file string
+		want interface{}
+	}{
+		{"apigatewayproxyevt/testdata/event-proxy.json", new(apigatewayproxyevt.Event)},
+		{"apigatewayauthorizerevt/testdata/event-token.json", new(apigatewayauthorizerevt.Event)},
+		{"cloudformationevt/testdata/event-create.json", new(cloudformationevt.Event)},
+		{"testdata/cloudwatchlogs.json", new(cloudwatchlogsevt.Event)},
+		{"cloudwatchschedevt/testdata/event-scheduled.json", new(cloudwatchschedevt.Event)},
+		{"codepipelineevt/testdata/event-job.json", new(codepipelineevt.Event)},
+		{"cognitosyncevt/testdata/event-sync-trigger.json", new(cognitosyncevt.Event)},
+		{"dynamodbstreamsevt/testdata/event-insert-modify-remove.json", new(dynamodbstreamsevt.Event)},
+		{"testdata/eventbridge.json", new(eventbridgeevt.Event)},
+		{"kinesisfirehoseevt/testdata/input-direct-put.json", new(kinesisfirehoseevt.Input)},
+		{"kinesisstreamsevt/testdata/event-records.json", new(kinesisstreamsevt.Event)},
+		{"s3evt/testdata/event-put.json", new(s3evt.Event)},
+		{"sesevt/testdata/event-receipt.json", new(sesevt.Event)},
+		{"snsevt/testdata/event-notification.json", new(snsevt.Event)},
+		{"sqsevt/testdata/event-fifo.json", new(sqsevt.Event)},
+	}
+
+	for _, tt := range tests {
+		data := readFile(t, tt.file)
+		if err := json.Unmarshal(data, tt.want); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := m.Handle(data)
+		if err != nil {
+			t.Errorf("%s: %v", tt.file, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %#v, want %#v", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestMuxHandleResult(t *testing.T) {
+	errTest := errors.New("test")
+
+	m := NewMux()
+	m.HandleS3(func(evt *s3evt.Event) (interface{}, error) {
+		return evt.Records[0].S3.Bucket.Name, nil
+	})
+	m.HandleSNS(func(evt *snsevt.Event) (interface{}, error) {
+		return nil, errTest
+	})
+
+	got, err := m.Handle(readFile(t, "s3evt/testdata/event-put.json"))
+	if got != "sourcebucket" || err != nil {
+		t.Errorf("got %v, %v", got, err)
+	}
+
+	got, err = m.Handle(readFile(t, "snsevt/testdata/event-notification.json"))
+	if got != nil || err != errTest {
+		t.Errorf("got %v, %v", got, err)
+	}
+}
+
+func TestMuxHandleUnregistered(t *testing.T) {
+	m := NewMux()
+	m.HandleS3(func(*s3evt.Event) (interface{}, error) {
+		t.Error("unexpected call")
+		return nil, nil
+	})
+
+	_, err := m.Handle(readFile(t, "snsevt/testdata/event-notification.json"))
+	if err == nil || err.Error() != "event: no handler for event source: sns" {
+		t.Errorf("got %v for a source without handler", err)
+	}
+
+	if _, err := m.Handle(json.RawMessage(`{"foo":"bar"}`)); err != ErrUnknownSource {
+		t.Errorf("got %v for an unknown source, want %v", err, ErrUnknownSource)
+	}
+
+	var fallback []string
+	m.HandleDefault(func(data json.RawMessage) (interface{}, error) {
+		fallback = append(fallback, string(Detect(data)))
+		return "default", nil
+	})
+	for _, data := range []json.RawMessage{readFile(t, "snsevt/testdata/event-notification.json"), json.RawMessage(`{"foo":"bar"}`)} {
+		if got, err := m.Handle(data); got != "default" || err != nil {
+			t.Errorf("got %v, %v from the default handler", got, err)
+		}
+	}
+	if want := []string{"sns", ""}; !reflect.DeepEqual(fallback, want) {
+		t.Errorf("got %q in the default handler, want %q", fallback, want)
+	}
+}
+
+func TestMuxHandleDecodeError(t *testing.T) {
+	m := NewMux()
+	m.HandleS3(func(*s3evt.Event) (interface{}, error) {
+		t.Error("unexpected call")
+		return nil, nil
+	})
+	m.HandleCloudWatchLogs(func(*cloudwatchlogsevt.Event) (interface{}, error) {
+		t.Error("unexpected call")
+		return nil, nil
+	})
+
+	for _, data := range []string{
+		`{"Records":[{"eventSource":"aws:s3","eventTime":"yesterday"}]}`,
+		`{"Records":[{"eventSource":"aws:s3","s3":"bucket"}]}`,
+		`{"awslogs":{"data":"bm90IGd6aXBwZWQ="}}`,
+	} {
+		got, err := m.Handle(json.RawMessage(data))
+		if got != nil || err == nil {
+			t.Errorf("%s: got %v, %v, want a decoding error", data, got, err)
+		}
+	}
+}
+
+func TestMuxHandleFunc(t *testing.T) {
+	m := NewMux()
+	m.HandleS3(func(*s3evt.Event) (interface{}, error) {
+		t.Error("unexpected call")
+		return nil, nil
+	})
+	m.HandleFunc(S3, func(data json.RawMessage) (interface{}, error) {
+		return len(data), nil
+	})
+
+	data := readFile(t, "s3evt/testdata/event-put.json")
+	if got, err := m.Handle(data); got != len(data) || err != nil {
+		t.Errorf("got %v, %v", got, err)
+	}
+}
@@ -0,0 +1,68 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package s3evt
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type eventRecordAlias EventRecord
+
+type timestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON interprets the data as a RFC3339 date and time. It then sets *t
+// to a copy of the interpreted time.
+func (t *timestamp) UnmarshalJSON(data []byte) error {
+	return t.Time.UnmarshalJSON(data)
+}
+
+// MarshalJSON returns t as a RFC3339 date and time in UTC with a millisecond
+// precision, as written by Amazon S3.
+func (t timestamp) MarshalJSON() ([]byte, error) {
+	return []byte(t.UTC().Format(`"2006-01-02T15:04:05.000Z"`)), nil
+}
+
+type jsonEventRecord struct {
+	*eventRecordAlias
+	EventTime timestamp `json:"eventTime"`
+}
+
+// UnmarshalJSON interprets data as an EventRecord with a special timestamp. It
+// then leverages type aliasing and struct embedding to fill EventRecord with an
+// usual time.Time.
+func (er *EventRecord) UnmarshalJSON(data []byte) error {
+	jer := jsonEventRecord{eventRecordAlias: (*eventRecordAlias)(er)}
+	if err := json.Unmarshal(data, &jer); err != nil {
+		return err
+	}
+
+	er.EventTime = jer.EventTime.Time
+
+	return nil
+}
+
+// MarshalJSON reverts the effect of type aliasing and struct embedding used
+// during the marshalling step to make the pattern seamless.
+func (er *EventRecord) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonEventRecord{
+		(*eventRecordAlias)(er),
+		timestamp{er.EventTime},
+	})
+}
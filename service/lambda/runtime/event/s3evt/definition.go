@@ -27,17 +27,17 @@ type Object struct {
 	// involved in the event.
 	// Note that the object keyname value is URL encoded. For example
 	// "red flower.jpg" becomes "red+flower.jpg".
-	Key string
+	Key string `json:"key"`
 
 	// The object size in bytes. Provided for "ObjectCreated" event,
 	// otherwise 0.
-	Size int
+	Size int `json:"size,omitempty"`
 
 	// The object ETag. Provided for "ObjectCreated" event, otherwise empty.
-	ETag string
+	ETag string `json:"eTag,omitempty"`
 
 	// The object version if bucket is versioning-enabled, otherwise empty.
-	VersionID string
+	VersionID string `json:"versionId,omitempty"`
 
 	// A string representation of a hexadecimal value used to determine
 	// event sequence, only used with PUTs and DELETEs. It provides a way
@@ -58,7 +58,7 @@ type Object struct {
 	// - The sequencers can be of different lengths. So to compare these
 	//   values, you first right pad the shorter value with zeros and then
 	//   do lexicographical comparison.
-	Sequencer string
+	Sequencer string `json:"sequencer"`
 }
 
 // UserIdentity represents details about an IAM identity,
@@ -67,26 +67,26 @@ type UserIdentity struct {
 	// made with temporary security credentials, this value includes the
 	// session name that is passed to the AssumeRole,
 	// AssumeRoleWithWebIdentity, or GetFederationToken API call.
-	PrincipalID string
+	PrincipalID string `json:"principalId"`
 }
 
 // Bucket provides information about the Amazon S3 bucket from which the event
 // originated.
 type Bucket struct {
 	// The bucket name.
-	Name string
+	Name string `json:"name"`
 
 	// The bucket ARN.
-	ARN string
+	ARN string `json:"arn"`
 
 	// The IAM identity of the Amazon S3 bucket owner.
-	OwnerIdentity *UserIdentity
+	OwnerIdentity *UserIdentity `json:"ownerIdentity"`
 }
 
 // Record represents the unit of data of an Amazon S3 notification.
 type Record struct {
 	// The schema version for the record.
-	S3SchemaVersion string
+	S3SchemaVersion string `json:"s3SchemaVersion"`
 
 	// The Amazon S3 notification feature enables you to receive
 	// notifications when certain events happen in your bucket. To enable
@@ -96,21 +96,21 @@ type Record struct {
 	// notifications. You store this configuration in the notification
 	// subresource associated with a bucket. ConfigurationID represents the
 	// ID of this configuration.
-	ConfigurationID string
+	ConfigurationID string `json:"configurationId"`
 
 	// Information about the Amazon S3 bucket from which the event
 	// originated.
-	Bucket *Bucket
+	Bucket *Bucket `json:"bucket"`
 
 	// Information about the Amazon S3 object that caused the event.
-	Object *Object
+	Object *Object `json:"object"`
 }
 
 // RequestParameters provides information about the request that caused the
 // event.
 type RequestParameters struct {
 	// The IP address of the requester.
-	SourceIPAddress string
+	SourceIPAddress string `json:"sourceIPAddress"`
 }
 
 // ResponseElements provides tracing information returned by Amazon S3 in the
@@ -128,32 +128,32 @@ type ResponseElements struct {
 type EventRecord struct {
 	// The Amazon S3 event name.
 	// See also http://docs.aws.amazon.com/AmazonS3/latest/dev/NotificationHowTo.html#notification-how-to-event-types-and-destinations
-	EventName string
+	EventName string `json:"eventName"`
 
 	// The time when Amazon S3 finished processing the request.
-	EventTime time.Time
+	EventTime time.Time `json:"-"`
 
 	// The event version.
-	EventVersion string
+	EventVersion string `json:"eventVersion"`
 
 	// The event Source.
-	EventSource string
+	EventSource string `json:"eventSource"`
 
 	// The IAM identity of the user who caused the event.
-	UserIdentity *UserIdentity
+	UserIdentity *UserIdentity `json:"userIdentity"`
 
 	// Information about the request that caused the event.
-	RequestParameters *RequestParameters
+	RequestParameters *RequestParameters `json:"requestParameters"`
 
 	// Tracing information returned by Amazon S3 in the response to the
 	// request that caused the event.
-	ResponseElements *ResponseElements
+	ResponseElements *ResponseElements `json:"responseElements"`
 
 	// The AWS region where the event originated.
-	AWSRegion string
+	AWSRegion string `json:"awsRegion"`
 
 	// The underlying Amazon S3 record associated with the event.
-	S3 *Record
+	S3 *Record `json:"s3"`
 }
 
 // String returns the string representation.
@@ -171,7 +171,7 @@ func (e *EventRecord) GoString() string {
 // See also http://docs.aws.amazon.com/AmazonS3/latest/dev/notification-content-structure.html
 type Event struct {
 	// The list of Amazon S3 event records.
-	Records []*EventRecord
+	Records []*EventRecord `json:"Records"`
 }
 
 // String returns the string representation.
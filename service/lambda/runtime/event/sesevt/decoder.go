@@ -23,6 +23,10 @@ import (
 
 type commonHeadersAlias CommonHeaders
 
+type mailAlias Mail
+
+type receiptAlias Receipt
+
 type timestamp struct {
 	time.Time
 }
@@ -40,21 +44,25 @@ func (t *timestamp) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalJSON returns t as a RFC5322 date and time.
+func (t timestamp) MarshalJSON() ([]byte, error) {
+	return []byte(t.Format(`"Mon, 2 Jan 2006 15:04:05 -0700"`)), nil
+}
+
 type jsonCommonHeaders struct {
 	*commonHeadersAlias
-	Date timestamp
+	Date timestamp `json:"date"`
 }
 
 // UnmarshalJSON interprets data as a CommonHeaders with a special timestamp.
 // It then leverages type aliasing and struct embedding to fill CommonHeaders
 // with an usual time.Time.
 func (ch *CommonHeaders) UnmarshalJSON(data []byte) error {
-	var jch jsonCommonHeaders
+	jch := jsonCommonHeaders{commonHeadersAlias: (*commonHeadersAlias)(ch)}
 	if err := json.Unmarshal(data, &jch); err != nil {
 		return err
 	}
 
-	*ch = *(*CommonHeaders)(jch.commonHeadersAlias)
 	ch.Date = jch.Date.Time
 
 	return nil
@@ -68,3 +76,75 @@ func (ch *CommonHeaders) MarshalJSON() ([]byte, error) {
 		timestamp{ch.Date},
 	})
 }
+
+type isoTimestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON interprets the data as a RFC3339 date and time. It then sets *t
+// to a copy of the interpreted time.
+func (t *isoTimestamp) UnmarshalJSON(data []byte) error {
+	return t.Time.UnmarshalJSON(data)
+}
+
+// MarshalJSON returns t as a RFC3339 date and time in UTC with a millisecond
+// precision, as written by Amazon SES.
+func (t isoTimestamp) MarshalJSON() ([]byte, error) {
+	return []byte(t.UTC().Format(`"2006-01-02T15:04:05.000Z"`)), nil
+}
+
+type jsonMail struct {
+	*mailAlias
+	Timestamp isoTimestamp `json:"timestamp"`
+}
+
+// UnmarshalJSON interprets data as a Mail with a special timestamp. It then
+// leverages type aliasing and struct embedding to fill Mail with an usual
+// time.Time.
+func (m *Mail) UnmarshalJSON(data []byte) error {
+	jm := jsonMail{mailAlias: (*mailAlias)(m)}
+	if err := json.Unmarshal(data, &jm); err != nil {
+		return err
+	}
+
+	m.Timestamp = jm.Timestamp.Time
+
+	return nil
+}
+
+// MarshalJSON reverts the effect of type aliasing and struct embedding used
+// during the marshalling step to make the pattern seamless.
+func (m *Mail) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonMail{
+		(*mailAlias)(m),
+		isoTimestamp{m.Timestamp},
+	})
+}
+
+type jsonReceipt struct {
+	*receiptAlias
+	Timestamp isoTimestamp `json:"timestamp"`
+}
+
+// UnmarshalJSON interprets data as a Receipt with a special timestamp. It then
+// leverages type aliasing and struct embedding to fill Receipt with an usual
+// time.Time.
+func (r *Receipt) UnmarshalJSON(data []byte) error {
+	jr := jsonReceipt{receiptAlias: (*receiptAlias)(r)}
+	if err := json.Unmarshal(data, &jr); err != nil {
+		return err
+	}
+
+	r.Timestamp = jr.Timestamp.Time
+
+	return nil
+}
+
+// MarshalJSON reverts the effect of type aliasing and struct embedding used
+// during the marshalling step to make the pattern seamless.
+func (r *Receipt) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonReceipt{
+		(*receiptAlias)(r),
+		isoTimestamp{r.Timestamp},
+	})
+}
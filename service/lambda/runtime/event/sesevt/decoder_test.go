@@ -0,0 +1,63 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package sesevt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestamp(t *testing.T) {
+	tests := []struct {
+		data string
+		want time.Time
+	}{
+		{`"Wed, 7 Oct 2015 12:34:56 -0700"`, time.Date(2015, 10, 7, 19, 34, 56, 0, time.UTC)},
+		{`"Fri, 17 Mar 2017 08:00:00 +0000"`, time.Date(2017, 3, 17, 8, 0, 0, 0, time.UTC)},
+		{`"Mon, 1 Jan 2018 01:00:00 +0100"`, time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		var ts timestamp
+		if err := json.Unmarshal([]byte(tt.data), &ts); err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if !ts.Equal(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.data, ts.Time, tt.want)
+		}
+
+		enc, err := json.Marshal(ts)
+		if err != nil {
+			t.Errorf("%s: %v", tt.data, err)
+			continue
+		}
+		if string(enc) != tt.data {
+			t.Errorf("%s: got %s", tt.data, enc)
+		}
+	}
+}
+
+func TestTimestampInvalid(t *testing.T) {
+	for _, data := range []string{`"2015-10-07T12:34:56Z"`, `1444246496`, `null`} {
+		var ts timestamp
+		if err := json.Unmarshal([]byte(data), &ts); err == nil {
+			t.Errorf("%s: expected error", data)
+		}
+	}
+}
@@ -24,10 +24,10 @@ import (
 // Header represents an header value
 type Header struct {
 	// The name of the header
-	Name string
+	Name string `json:"name"`
 
 	// The value of the header
-	Value string
+	Value string `json:"value"`
 }
 
 // CommonHeaders represents a list of headers common to all emails.
@@ -39,7 +39,7 @@ type CommonHeaders struct {
 	// the MessageID is what is contained between the two angle bracket
 	// characters.
 	// Format: <id-left@id-right>
-	MessageID string
+	MessageID string `json:"messageId"`
 
 	// Specifies the date and time at which the creator of the message indicated
 	// that the message was complete and ready to enter the mail delivery system.
@@ -51,18 +51,18 @@ type CommonHeaders struct {
 	// a reply, the field body MAY start with the string "Re: " (an abbreviation
 	// of the Latin "in re", meaning "in the matter of") followed by the contents
 	// of the "Subject:" field body of the original message.
-	Subject string
+	Subject string `json:"subject"`
 
 	// Specifies the author(s) of the message, that is, the mailbox(es) of the
 	// person(s) or system(s) responsible for the writing of the message.
-	From []string
+	From []string `json:"from"`
 
 	// Contains the address(es) of the primary recipient(s) of the message.
-	To []string
+	To []string `json:"to"`
 
 	// Intended to show the envelope address of the real sender as opposed to the
 	// sender used for replying (the From: and Reply-To: headers).
-	ReturnPath string
+	ReturnPath string `json:"returnPath"`
 }
 
 // Verdict encapsulates information about the check that was executed.
@@ -70,23 +70,23 @@ type Verdict struct {
 	// The status of the verdict, can be one of "PASS", "FAIL", "GRAY" or
 	// "PROCESSING_FAILED". See each check to know more about the signification
 	// of each status.
-	Status string
+	Status string `json:"status"`
 }
 
 // Action encapsulates information about the action that was executed.
 type Action struct {
 	// Indicates the type of action that was executed.
 	// In our case value is always "Lambda".
-	Type string
+	Type string `json:"type"`
 
 	// Contains the invocation type of the AWS Lambda function.
 	// Possible values are "RequestResponse"" and "Event". Present only for
 	// the AWS Lambda action type.
-	InvocationType string
+	InvocationType string `json:"invocationType"`
 
 	// Contains the ARN of the AWS Lambda function that was triggered.
 	// Present only for the AWS Lambda action type.
-	FunctionARN string
+	FunctionARN string `json:"functionArn"`
 }
 
 // Mail contains information about the email to which the notification pertains.
@@ -97,28 +97,28 @@ type Mail struct {
 	// Note that this message ID was assigned by Amazon SES. You can find
 	// the message ID of the original email in the headers and CommonHeaders
 	// object of the Mail object.
-	MessageID string
+	MessageID string `json:"messageId"`
 
 	// The time at which the email was received.
-	Timestamp time.Time
+	Timestamp time.Time `json:"-"`
 
 	// The email address from which the email was sent (the envelope
 	// MAIL FROM address).
-	Source string
+	Source string `json:"source"`
 
 	// A list of email addresses that were recipients of the original mail.
-	Destination []string
+	Destination []string `json:"destination"`
 
 	// A list of headers common to all emails.
 	// Note that any message ID within the CommonHeaders object is from the
 	// original message that you passed to Amazon SES. The message ID that
 	// Amazon SES subsequently assigned to the message is in the MessageID
 	// field of the Mail object.
-	CommonHeaders *CommonHeaders
+	CommonHeaders *CommonHeaders `json:"commonHeaders"`
 
 	// Specifies whether the headers were truncated in the notification,
 	// which will happen if the headers are larger than 10 KB.
-	HeadersTruncated bool
+	HeadersTruncated bool `json:"headersTruncated"`
 
 	// A list of the email's original headers. Each header in the list has a
 	// name field and a value field.
@@ -128,24 +128,24 @@ type Mail struct {
 	// field of the Mail object.
 	// See https://tools.ietf.org/search/rfc5322 for more information about
 	// headers.
-	Headers []*Header
+	Headers []*Header `json:"headers"`
 }
 
 // Receipt contains information about the email delivery.
 type Receipt struct {
 	// A list of the recipient addresses for this delivery. This list might
 	// be a subset of the recipients to which the mail was addressed.
-	Recipients []string
+	Recipients []string `json:"recipients"`
 
 	// Specifies when the action was triggered.
-	Timestamp time.Time
+	Timestamp time.Time `json:"-"`
 
 	// Specifies the period, in milliseconds, from the time Amazon SES
 	// received the message to the time it triggered the action.
-	ProcessingTimeMillis int
+	ProcessingTimeMillis int `json:"processingTimeMillis"`
 
 	// Encapsulates information about the action that was executed.
-	Action *Action
+	Action *Action `json:"action"`
 
 	// Indicates whether the message is spam. Possible values are as
 	// follows:
@@ -155,7 +155,7 @@ type Receipt struct {
 	//   confidence whether it is spam.
 	// - PROCESSING_FAILED: Amazon SES is unable to scan the content of the
 	//   email. For example, the email is not a valid MIME message.
-	SpamVerdict *Verdict
+	SpamVerdict *Verdict `json:"spamVerdict"`
 
 	// Indicates whether the DomainKeys Identified Mail (DKIM) check passed.
 	// Possible values are as follows:
@@ -165,7 +165,7 @@ type Receipt struct {
 	// - PROCESSING_FAILED: there is an issue that prevents Amazon SES from
 	//   checking the DKIM signature. For example, DNS queries are failing
 	//   or the DKIM signature header is not formatted properly.
-	DKIMVerdict *Verdict
+	DKIMVerdict *Verdict `json:"dkimVerdict"`
 
 	// Indicates whether the Sender Policy Framework (SPF) check passed.
 	// Possible values are as follows:
@@ -175,7 +175,7 @@ type Receipt struct {
 	//   command.
 	// - PROCESSING_FAILED: there is an issue that prevents Amazon SES from
 	//   checking the SPF record. For example, DNS queries are failing.
-	SPFVerdict *Verdict
+	SPFVerdict *Verdict `json:"spfVerdict"`
 
 	// Indicates whether the message contains a virus. Possible values are
 	// as follows:
@@ -185,7 +185,7 @@ type Receipt struct {
 	//   confidence whether it contains a virus.
 	// - PROCESSING_FAILED: Amazon SES is unable to scan the content of the
 	//   email. For example, the email is not a valid MIME message.
-	VirusVerdict *Verdict
+	VirusVerdict *Verdict `json:"virusVerdict"`
 }
 
 // Record represents the unit of data of an Amazon SES message.
@@ -193,22 +193,22 @@ type Receipt struct {
 type Record struct {
 	// Contains information about the email to which the notification
 	// pertains.
-	Mail *Mail
+	Mail *Mail `json:"mail"`
 
 	// Contains information about the email delivery.
-	Receipt *Receipt
+	Receipt *Receipt `json:"receipt"`
 }
 
 // EventRecord provides contextual information about an Amazon SES event.
 type EventRecord struct {
 	// The event version.
-	EventVersion string
+	EventVersion string `json:"eventVersion"`
 
 	// The event source.
-	EventSource string
+	EventSource string `json:"eventSource"`
 
 	// The underlying Amazon SES record associated with the event.
-	SES *Record
+	SES *Record `json:"ses"`
 }
 
 // String returns the string representation.
@@ -225,7 +225,7 @@ func (e *EventRecord) GoString() string {
 // Event represents an Amazon SES event.
 type Event struct {
 	// The list of Amazon SES event records.
-	Records []*EventRecord
+	Records []*EventRecord `json:"Records"`
 }
 
 // String returns the string representation.
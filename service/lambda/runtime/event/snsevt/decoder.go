@@ -0,0 +1,96 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package snsevt
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type recordAlias Record
+
+type timestamp struct {
+	time.Time
+
+	// The timestamp as written by Amazon SNS.
+	raw string
+}
+
+// UnmarshalJSON interprets the data as a RFC3339 date and time. It then sets *t
+// to a copy of the interpreted time and keeps the data as written.
+func (t *timestamp) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &t.raw); err != nil {
+		return err
+	}
+	return t.Time.UnmarshalJSON(data)
+}
+
+// MarshalJSON returns t as written by Amazon SNS when it still matches the
+// time, or as a RFC3339 date and time in UTC with a millisecond precision,
+// which is what Amazon SNS writes.
+func (t timestamp) MarshalJSON() ([]byte, error) {
+	if raw, ok := wireTimestamp(t.raw, t.Time); ok {
+		return json.Marshal(raw)
+	}
+	return []byte(t.UTC().Format(`"2006-01-02T15:04:05.000Z"`)), nil
+}
+
+type jsonRecord struct {
+	*recordAlias
+	Timestamp timestamp `json:"Timestamp"`
+}
+
+// UnmarshalJSON interprets data as a Record with a special timestamp. It then
+// leverages type aliasing and struct embedding to fill Record with an usual
+// time.Time.
+func (r *Record) UnmarshalJSON(data []byte) error {
+	jr := jsonRecord{recordAlias: (*recordAlias)(r)}
+	if err := json.Unmarshal(data, &jr); err != nil {
+		return err
+	}
+
+	r.Timestamp, r.rawTimestamp = jr.Timestamp.Time, jr.Timestamp.raw
+
+	return nil
+}
+
+// MarshalJSON reverts the effect of type aliasing and struct embedding used
+// during the marshalling step to make the pattern seamless.
+func (r *Record) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonRecord{
+		(*recordAlias)(r),
+		timestamp{r.Timestamp, r.rawTimestamp},
+	})
+}
+
+// wireTimestamp returns the timestamp as written by Amazon SNS, provided it has
+// not been altered since.
+func (r *Record) wireTimestamp() (string, bool) {
+	return wireTimestamp(r.rawTimestamp, r.Timestamp)
+}
+
+// wireTimestamp returns raw if it is the RFC3339 representation of t.
+func wireTimestamp(raw string, t time.Time) (string, bool) {
+	if raw == "" {
+		return "", false
+	}
+	v, err := time.Parse(time.RFC3339, raw)
+	if err != nil || !v.Equal(t) {
+		return "", false
+	}
+	return raw, true
+}
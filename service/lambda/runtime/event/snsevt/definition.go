@@ -24,10 +24,10 @@ import (
 // MessageAttributes represents an SNS message attribute.
 type MessageAttributes struct {
 	// The attribute type.
-	Type string
+	Type string `json:"Type"`
 
 	// The attribute value.
-	Value string
+	Value string `json:"Value"`
 }
 
 // Record represents the unit of data of an Amazon SNS message.
@@ -36,68 +36,71 @@ type Record struct {
 	// A Universally Unique Identifier, unique for each message published.
 	// For a message that Amazon SNS resends during a retry, the message ID
 	// of the original message is used.
-	MessageID string
+	MessageID string `json:"MessageId"`
 
 	// The type of the message.
-	Type string
+	Type string `json:"Type"`
 
 	// The time when the notification was published.
-	Timestamp time.Time
+	Timestamp time.Time `json:"-"`
 
 	// The Subject parameter specified when the notification was published
 	// to the topic.
 	// Note that this is an optional parameter.
-	Subject string
+	Subject string `json:"Subject"`
 
 	// The Message value specified when the notification was published to
 	// the topic.
-	Message string
+	Message string `json:"Message"`
 
 	// The attributes associated with the message.
-	MessageAttributes map[string]*MessageAttributes
+	MessageAttributes map[string]*MessageAttributes `json:"MessageAttributes"`
 
 	// The Version of the Amazon SNS signature used.
-	SignatureVersion string
+	SignatureVersion string `json:"SignatureVersion"`
 
 	// Base64-encoded "SHA1withRSA" signature of the Message, MessageID,
 	// Subject (if present), Type, Timestamp, and TopicARN values.
 	// See also http://docs.aws.amazon.com/sns/latest/dg/SendMessageToHttp.verify.signature.html
-	Signature string
+	Signature string `json:"Signature"`
 
 	// The URL to the certificate that was used to sign the message.
-	SignatureCertURL string
+	SignatureCertURL string `json:"SigningCertUrl"`
 
 	// The URL that you must visit in order to confirm the subscription.
 	// Alternatively, you can instead use the Token with the
 	// ConfirmSubscription action to confirm the subscription.
-	SubscribeURL string
+	SubscribeURL string `json:"SubscribeURL,omitempty"`
 
 	// A value you can use with the ConfirmSubscription action to confirm the
 	// subscription. Alternatively, you can simply visit the SubscribeURL.
-	Token string
+	Token string `json:"Token,omitempty"`
 
 	// The ARN of the topic that this message was published to.
-	TopicARN string
+	TopicARN string `json:"TopicArn"`
 
 	// An URL that you can use to unsubscribe the endpoint from this topic.
 	// If you visit this URL, Amazon SNS unsubscribes the endpoint and stops
 	// sending notifications to this endpoint.
-	UnsubscribeURL string
+	UnsubscribeURL string `json:"UnsubscribeUrl"`
+
+	// The timestamp as written by Amazon SNS, which is the value it signs.
+	rawTimestamp string
 }
 
 // EventRecord provides contextual information about an Amazon SNS event.
 type EventRecord struct {
 	// The event version.
-	EventVersion string
+	EventVersion string `json:"EventVersion"`
 
 	// The event source.
-	EventSource string
+	EventSource string `json:"EventSource"`
 
 	// The ARN of the Lambda subscription.
-	EventSubscriptionARN string
+	EventSubscriptionARN string `json:"EventSubscriptionArn"`
 
 	// The underlying Amazon SNS record associated with the event.
-	SNS *Record
+	SNS *Record `json:"Sns"`
 }
 
 // String returns the string representation.
@@ -114,7 +117,7 @@ func (e *EventRecord) GoString() string {
 // Event represents an Amazon SNS event.
 type Event struct {
 	// The list of Amazon SNS event records.
-	Records []*EventRecord
+	Records []*EventRecord `json:"Records"`
 }
 
 // String returns the string representation.
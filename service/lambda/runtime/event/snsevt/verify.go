@@ -0,0 +1,247 @@
+//
+// Copyright 2017 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package snsevt
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrInvalidSignature is returned when the signature of a message does
+	// not match its content.
+	ErrInvalidSignature = errors.New("snsevt: invalid signature")
+
+	// ErrInvalidCertURL is returned when the signing certificate URL of a
+	// message does not point to an Amazon SNS certificate.
+	ErrInvalidCertURL = errors.New("snsevt: invalid signing certificate URL")
+)
+
+// certHost matches the host of the Amazon SNS signing certificates.
+var certHost = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// CertCache stores the certificates fetched by a Verifier.
+type CertCache interface {
+	// Get returns the certificate stored for the URL, if any.
+	Get(url string) (*x509.Certificate, bool)
+
+	// Put stores the certificate fetched from the URL.
+	Put(url string, cert *x509.Certificate)
+}
+
+type memoryCache struct {
+	mu    sync.RWMutex
+	certs map[string]*x509.Certificate
+}
+
+// NewMemoryCache returns a CertCache which keeps the certificates in memory,
+// for the lifetime of the AWS Lambda container.
+func NewMemoryCache() CertCache {
+	return &memoryCache{certs: make(map[string]*x509.Certificate)}
+}
+
+func (c *memoryCache) Get(url string) (*x509.Certificate, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	cert, ok := c.certs[url]
+	return cert, ok
+}
+
+func (c *memoryCache) Put(url string, cert *x509.Certificate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.certs[url] = cert
+}
+
+// Verifier verifies the signature of Amazon SNS messages.
+type Verifier struct {
+	// Fetch retrieves the certificate at the given URL.
+	// If nil, the certificate is downloaded with http.DefaultClient.
+	Fetch func(url string) (*x509.Certificate, error)
+
+	// Cache stores the fetched certificates.
+	// If nil, the certificate is fetched for each verification.
+	Cache CertCache
+}
+
+// DefaultVerifier is the Verifier used by Verify. It keeps the fetched
+// certificates in memory.
+var DefaultVerifier = &Verifier{Cache: NewMemoryCache()}
+
+// Verify verifies the signature of the record using DefaultVerifier.
+func Verify(r *Record) error {
+	return DefaultVerifier.Verify(r)
+}
+
+// Verify verifies the signature of the record. It supports the Notification,
+// SubscriptionConfirmation and UnsubscribeConfirmation message types, and the
+// signature versions 1 (SHA1withRSA) and 2 (SHA256withRSA).
+// See http://docs.aws.amazon.com/sns/latest/dg/SendMessageToHttp.verify.signature.html
+func (v *Verifier) Verify(r *Record) error {
+	var h crypto.Hash
+	switch r.SignatureVersion {
+	case "1":
+		h = crypto.SHA1
+	case "2":
+		h = crypto.SHA256
+	default:
+		return fmt.Errorf("snsevt: unsupported signature version: %q", r.SignatureVersion)
+	}
+
+	msg, err := StringToSign(r)
+	if err != nil {
+		return err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(r.Signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	cert, err := v.certificate(r.SignatureCertURL)
+	if err != nil {
+		return err
+	}
+	pub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("snsevt: unsupported public key type: %T", cert.PublicKey)
+	}
+
+	var sum []byte
+	if h == crypto.SHA1 {
+		s := sha1.Sum(msg)
+		sum = s[:]
+	} else {
+		s := sha256.Sum256(msg)
+		sum = s[:]
+	}
+	if err := rsa.VerifyPKCS1v15(pub, h, sum, sig); err != nil {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// StringToSign returns the canonical string Amazon SNS signs for the record,
+// made of the name and value of the signed fields, each one on its own line.
+// The timestamp is taken as received, or formatted with millisecond precision
+// for records which have not been decoded from JSON.
+func StringToSign(r *Record) ([]byte, error) {
+	var keys []string
+	switch r.Type {
+	case "Notification":
+		keys = []string{"Message", "MessageId", "Subject", "Timestamp", "TopicArn", "Type"}
+	case "SubscriptionConfirmation", "UnsubscribeConfirmation":
+		keys = []string{"Message", "MessageId", "SubscribeURL", "Timestamp", "Token", "TopicArn", "Type"}
+	default:
+		return nil, fmt.Errorf("snsevt: unsupported message type: %q", r.Type)
+	}
+
+	ts, ok := r.wireTimestamp()
+	if !ok {
+		ts = r.Timestamp.UTC().Format("2006-01-02T15:04:05.000Z")
+	}
+
+	values := map[string]string{
+		"Message":      r.Message,
+		"MessageId":    r.MessageID,
+		"Subject":      r.Subject,
+		"SubscribeURL": r.SubscribeURL,
+		"Timestamp":    ts,
+		"Token":        r.Token,
+		"TopicArn":     r.TopicARN,
+		"Type":         r.Type,
+	}
+
+	var buf bytes.Buffer
+	for _, k := range keys {
+		if k == "Subject" && r.Subject == "" {
+			continue
+		}
+		buf.WriteString(k)
+		buf.WriteByte('\n')
+		buf.WriteString(values[k])
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+func (v *Verifier) certificate(certURL string) (*x509.Certificate, error) {
+	u, err := url.Parse(certURL)
+	if err != nil || u.Scheme != "https" || !certHost.MatchString(u.Host) || !strings.HasSuffix(u.Path, ".pem") {
+		return nil, ErrInvalidCertURL
+	}
+
+	if v.Cache != nil {
+		if cert, ok := v.Cache.Get(certURL); ok {
+			return cert, nil
+		}
+	}
+
+	fetch := v.Fetch
+	if fetch == nil {
+		fetch = fetchCertificate
+	}
+	cert, err := fetch(certURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Cache != nil {
+		v.Cache.Put(certURL, cert)
+	}
+	return cert, nil
+}
+
+// fetchCertificate downloads and parses the PEM encoded certificate at the
+// given URL.
+func fetchCertificate(certURL string) (*x509.Certificate, error) {
+	resp, err := http.Get(certURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("snsevt: unexpected certificate response status: %s", resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("snsevt: no PEM certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
@@ -0,0 +1,40 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package sqsevt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// BaseType returns the data type of the attribute without its custom suffix,
+// that is one of DataTypeString, DataTypeNumber or DataTypeBinary.
+func (a *MessageAttribute) BaseType() string {
+	if i := strings.IndexByte(a.DataType, '.'); i >= 0 {
+		return a.DataType[:i]
+	}
+	return a.DataType
+}
+
+// Number returns the value of a Number attribute.
+func (a *MessageAttribute) Number() (json.Number, error) {
+	if a.BaseType() != DataTypeNumber || a.StringValue == nil {
+		return "", fmt.Errorf("sqsevt: not a number attribute: %s", a.DataType)
+	}
+	return json.Number(*a.StringValue), nil
+}
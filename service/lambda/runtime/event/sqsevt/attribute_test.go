@@ -0,0 +1,57 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package sqsevt
+
+import "testing"
+
+func TestMessageAttributeBaseType(t *testing.T) {
+	tests := map[string]string{
+		"String":            DataTypeString,
+		"String.email":      DataTypeString,
+		"Number":            DataTypeNumber,
+		"Number.float.unit": DataTypeNumber,
+		"Binary.png":        DataTypeBinary,
+	}
+	for typ, want := range tests {
+		if got := (&MessageAttribute{DataType: typ}).BaseType(); got != want {
+			t.Errorf("%s: got %q, want %q", typ, got, want)
+		}
+	}
+}
+
+func TestMessageAttributeNumber(t *testing.T) {
+	attrs := readEvent(t, "testdata/event-fifo.json").Records[0].MessageAttributes
+
+	for name, want := range map[string]string{"Population": "1250800", "Temperature": "23.5"} {
+		n, err := attrs[name].Number()
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if n.String() != want {
+			t.Errorf("%s: got %s, want %s", name, n, want)
+		}
+	}
+	for _, name := range []string{"City", "Thumbnail"} {
+		if _, err := attrs[name].Number(); err == nil {
+			t.Errorf("%s: got no error", name)
+		}
+	}
+	if _, err := (&MessageAttribute{DataType: DataTypeNumber}).Number(); err == nil {
+		t.Error("got no error for a number without value")
+	}
+}
@@ -0,0 +1,74 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package sqsevt
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/eawsy/aws-lambda-go-event/service/lambda/runtime/event/internal/batch"
+)
+
+// BatchItemFailure identifies a message which failed to be processed.
+type BatchItemFailure struct {
+	// The ID of the message.
+	ItemIdentifier string `json:"itemIdentifier"`
+}
+
+// BatchResponse represents the partial batch response of an AWS Lambda function
+// processing Amazon SQS events. The function must be configured to report
+// batch item failures, in which case only the failed messages are made
+// visible again in the queue, the other ones being deleted.
+type BatchResponse struct {
+	// The list of messages which failed to be processed.
+	BatchItemFailures []BatchItemFailure `json:"batchItemFailures"`
+}
+
+// String returns the string representation.
+func (r *BatchResponse) String() string {
+	s, _ := json.Marshal(r)
+	return string(s)
+}
+
+// GoString returns the string representation.
+func (r *BatchResponse) GoString() string {
+	return r.String()
+}
+
+// RecordHandlerFunc processes a single event record.
+type RecordHandlerFunc func(ctx context.Context, rec *EventRecord) error
+
+// Process runs the handler for each record of the event, in order, and returns
+// the partial batch response to send back to AWS Lambda. A record is reported
+// as failed if its handler fails or panics, or if ctx is done before it is
+// processed.
+// Messages of FIFO queues must be processed in order, so Process stops at the
+// first failure and reports the remaining messages as failed as well.
+func (e *Event) Process(ctx context.Context, h RecordHandlerFunc) *BatchResponse {
+	resp := &BatchResponse{BatchItemFailures: []BatchItemFailure{}}
+	fifo := func(i int) bool {
+		rec := e.Records[i]
+		return rec.Attributes != nil && rec.Attributes.MessageGroupID != ""
+	}
+	failed := batch.Process(ctx, len(e.Records), fifo, func(ctx context.Context, i int) error {
+		return h(ctx, e.Records[i])
+	})
+	for _, i := range failed {
+		resp.BatchItemFailures = append(resp.BatchItemFailures, BatchItemFailure{e.Records[i].MessageID})
+	}
+	return resp
+}
@@ -0,0 +1,109 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package sqsevt
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newTestEvent(group string, ids ...string) *Event {
+	e := &Event{}
+	for _, id := range ids {
+		e.Records = append(e.Records, &EventRecord{MessageID: id, Attributes: &Attributes{MessageGroupID: group}})
+	}
+	return e
+}
+
+func TestProcess(t *testing.T) {
+	tests := []struct {
+		name   string
+		event  *Event
+		called []string
+		failed []BatchItemFailure
+	}{
+		{
+			name:   "standard queue",
+			event:  newTestEvent("", "a", "b", "c", "d"),
+			called: []string{"a", "b", "c", "d"},
+			failed: []BatchItemFailure{{"b"}, {"d"}},
+		},
+		{
+			name:   "FIFO queue",
+			event:  newTestEvent("g", "a", "b", "c", "d"),
+			called: []string{"a", "b"},
+			failed: []BatchItemFailure{{"b"}, {"c"}, {"d"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := []string{}
+			resp := tt.event.Process(context.Background(), func(ctx context.Context, rec *EventRecord) error {
+				called = append(called, rec.MessageID)
+				switch rec.MessageID {
+				case "b":
+					return errors.New("failed")
+				case "d":
+					panic("boom")
+				}
+				return nil
+			})
+
+			if !reflect.DeepEqual(called, tt.called) {
+				t.Errorf("got calls %v, want %v", called, tt.called)
+			}
+			if !reflect.DeepEqual(resp.BatchItemFailures, tt.failed) {
+				t.Errorf("got failures %v, want %v", resp.BatchItemFailures, tt.failed)
+			}
+		})
+	}
+}
+
+func TestProcessEvent(t *testing.T) {
+	for file, want := range map[string]string{
+		"testdata/event-standard.json": `{"batchItemFailures":[{"itemIdentifier":"2e1424d4-f796-459a-8184-9c92662be6da"}]}`,
+		"testdata/event-fifo.json":     `{"batchItemFailures":[{"itemIdentifier":"11d6ee51-4cc7-4302-9e22-7cd8afdaadf5"}]}`,
+	} {
+		evt := readEvent(t, file)
+		last := evt.Records[len(evt.Records)-1]
+		resp := evt.Process(context.Background(), func(ctx context.Context, rec *EventRecord) error {
+			if rec == last {
+				return errors.New("failed")
+			}
+			return nil
+		})
+		if s := resp.String(); s != want {
+			t.Errorf("%s: got %s, want %s", file, s, want)
+		}
+	}
+}
+
+func TestProcessCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	resp := newTestEvent("", "a", "b").Process(ctx, func(ctx context.Context, rec *EventRecord) error {
+		t.Error("unexpected call")
+		return nil
+	})
+	if want := []BatchItemFailure{{"a"}, {"b"}}; !reflect.DeepEqual(resp.BatchItemFailures, want) {
+		t.Errorf("got failures %v, want %v", resp.BatchItemFailures, want)
+	}
+}
@@ -0,0 +1,91 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package sqsevt
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+type attributesAlias Attributes
+
+type timestamp struct {
+	time.Time
+}
+
+// UnmarshalJSON interprets the data as a string holding the number of
+// milliseconds elapsed since January 1, 1970 00:00:00 UTC. It then sets *t to
+// a copy of the interpreted time.
+func (t *timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	t.Time = time.Unix(v/1e3, v%1e3*int64(time.Millisecond))
+	return nil
+}
+
+// MarshalJSON returns t as a string holding the number of milliseconds elapsed
+// since January 1, 1970 00:00:00 UTC, or null if t is the zero time.
+func (t timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	v := t.Unix()*1e3 + int64(t.Nanosecond())/int64(time.Millisecond)
+	return []byte(strconv.Quote(strconv.FormatInt(v, 10))), nil
+}
+
+type jsonAttributes struct {
+	*attributesAlias
+	SentTimestamp                    timestamp `json:"SentTimestamp"`
+	ApproximateFirstReceiveTimestamp timestamp `json:"ApproximateFirstReceiveTimestamp"`
+}
+
+// UnmarshalJSON interprets data as Attributes with special timestamps. It then
+// leverages type aliasing and struct embedding to fill Attributes with usual
+// time.Time.
+func (a *Attributes) UnmarshalJSON(data []byte) error {
+	ja := jsonAttributes{attributesAlias: (*attributesAlias)(a)}
+	if err := json.Unmarshal(data, &ja); err != nil {
+		return err
+	}
+
+	a.SentTimestamp = ja.SentTimestamp.Time
+	a.ApproximateFirstReceiveTimestamp = ja.ApproximateFirstReceiveTimestamp.Time
+
+	return nil
+}
+
+// MarshalJSON reverts the effect of type aliasing and struct embedding used
+// during the marshalling step to make the pattern seamless.
+func (a *Attributes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&jsonAttributes{
+		(*attributesAlias)(a),
+		timestamp{a.SentTimestamp},
+		timestamp{a.ApproximateFirstReceiveTimestamp},
+	})
+}
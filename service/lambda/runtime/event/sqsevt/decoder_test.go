@@ -0,0 +1,86 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package sqsevt
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestAttributesUnmarshal(t *testing.T) {
+	data := `{"ApproximateReceiveCount":"42","SenderId":"AIDAIENQZJOLO23YVJ4VO","SentTimestamp":"1545082649183","ApproximateFirstReceiveTimestamp":"1545082649005"}`
+
+	var a Attributes
+	if err := json.Unmarshal([]byte(data), &a); err != nil {
+		t.Fatal(err)
+	}
+	if a.ApproximateReceiveCount != 42 {
+		t.Errorf("got receive count %d, want 42", a.ApproximateReceiveCount)
+	}
+	if want := time.Unix(1545082649, 183e6); !a.SentTimestamp.Equal(want) {
+		t.Errorf("got sent timestamp %v, want %v", a.SentTimestamp, want)
+	}
+	if want := time.Unix(1545082649, 5e6); !a.ApproximateFirstReceiveTimestamp.Equal(want) {
+		t.Errorf("got first receive timestamp %v, want %v", a.ApproximateFirstReceiveTimestamp, want)
+	}
+	if a.SenderID != "AIDAIENQZJOLO23YVJ4VO" {
+		t.Errorf("got sender %q", a.SenderID)
+	}
+
+	out, err := json.Marshal(&a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != data {
+		t.Errorf("got %s, want %s", out, data)
+	}
+}
+
+func TestAttributesMarshalZero(t *testing.T) {
+	out, err := json.Marshal(&Attributes{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"ApproximateReceiveCount":"0","SenderId":"","SentTimestamp":null,"ApproximateFirstReceiveTimestamp":null}`
+	if string(out) != want {
+		t.Errorf("got %s, want %s", out, want)
+	}
+
+	var a Attributes
+	if err := json.Unmarshal(out, &a); err != nil {
+		t.Fatal(err)
+	}
+	if !a.SentTimestamp.IsZero() || !a.ApproximateFirstReceiveTimestamp.IsZero() {
+		t.Errorf("got %v and %v, want zero times", a.SentTimestamp, a.ApproximateFirstReceiveTimestamp)
+	}
+}
+
+func TestAttributesUnmarshalError(t *testing.T) {
+	for _, data := range []string{
+		`{"SentTimestamp":1545082649183}`,
+		`{"SentTimestamp":"2018-12-17T21:37:29Z"}`,
+		`{"ApproximateFirstReceiveTimestamp":"soon"}`,
+		`{"ApproximateReceiveCount":"many"}`,
+		`{"ApproximateReceiveCount":3}`,
+	} {
+		var a Attributes
+		if err := json.Unmarshal([]byte(data), &a); err == nil {
+			t.Errorf("%s: got no error", data)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package sqsevt
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// The data types of a message attribute. Custom types, such as "Number.float"
+// or "Binary.gif", are suffixed to one of them.
+const (
+	DataTypeString = "String"
+	DataTypeNumber = "Number"
+	DataTypeBinary = "Binary"
+)
+
+// MessageAttribute represents a custom metadata sent along with the message.
+type MessageAttribute struct {
+	// The data type of the attribute.
+	DataType string `json:"dataType"`
+
+	// The attribute value, for String and Number attributes.
+	StringValue *string `json:"stringValue,omitempty"`
+
+	// The attribute value, for Binary attributes.
+	BinaryValue []byte `json:"binaryValue,omitempty"`
+
+	// Reserved for future use.
+	StringListValues []string `json:"stringListValues"`
+
+	// Reserved for future use.
+	BinaryListValues [][]byte `json:"binaryListValues"`
+}
+
+// Attributes provides the system attributes of the message.
+type Attributes struct {
+	// The number of times the message has been received across all queues
+	// but not deleted.
+	ApproximateReceiveCount int `json:"ApproximateReceiveCount,string"`
+
+	// The time the message was sent to the queue.
+	SentTimestamp time.Time `json:"SentTimestamp"`
+
+	// The IAM user ID for IAM users, or the IAM role ID followed by the
+	// session name for IAM roles, of the sender.
+	SenderID string `json:"SenderId"`
+
+	// The time the message was first received from the queue.
+	ApproximateFirstReceiveTimestamp time.Time `json:"ApproximateFirstReceiveTimestamp"`
+
+	// The tag that specifies that the message belongs to a specific message
+	// group. Provided for FIFO queues only, otherwise empty.
+	MessageGroupID string `json:"MessageGroupId,omitempty"`
+
+	// The token used for deduplication of sent messages. Provided for FIFO
+	// queues only, otherwise empty.
+	MessageDeduplicationID string `json:"MessageDeduplicationId,omitempty"`
+
+	// The large, non-consecutive number Amazon SQS assigns to each message.
+	// Provided for FIFO queues only, otherwise empty.
+	SequenceNumber string `json:"SequenceNumber,omitempty"`
+
+	// The AWS X-Ray trace header string, if any.
+	AWSTraceHeader string `json:"AWSTraceHeader,omitempty"`
+}
+
+// EventRecord provides contextual data about an Amazon SQS message.
+type EventRecord struct {
+	// A unique identifier for the message.
+	MessageID string `json:"messageId"`
+
+	// An identifier associated with the act of receiving the message, used
+	// to delete the message or change its visibility.
+	ReceiptHandle string `json:"receiptHandle"`
+
+	// The message contents.
+	Body string `json:"body"`
+
+	// The system attributes of the message.
+	Attributes *Attributes `json:"attributes"`
+
+	// The custom attributes of the message.
+	MessageAttributes map[string]*MessageAttribute `json:"messageAttributes"`
+
+	// The MD5 digest of the message body, in hexadecimal.
+	MD5OfBody string `json:"md5OfBody"`
+
+	// The MD5 digest of the message attributes, in hexadecimal. Provided
+	// when the message has custom attributes, otherwise empty.
+	MD5OfMessageAttributes string `json:"md5OfMessageAttributes,omitempty"`
+
+	// The event source.
+	EventSource string `json:"eventSource"`
+
+	// The ARN of the queue.
+	EventSourceARN string `json:"eventSourceARN"`
+
+	// The AWS region where the queue is located.
+	AWSRegion string `json:"awsRegion"`
+}
+
+// String returns the string representation.
+func (e *EventRecord) String() string {
+	s, _ := json.Marshal(e)
+	return string(s)
+}
+
+// GoString returns the string representation.
+func (e *EventRecord) GoString() string {
+	return e.String()
+}
+
+// Event represents an Amazon SQS event.
+// See also http://docs.aws.amazon.com/lambda/latest/dg/with-sqs.html
+type Event struct {
+	// The list of Amazon SQS event records.
+	Records []*EventRecord `json:"Records"`
+}
+
+// String returns the string representation.
+func (e *Event) String() string {
+	s, _ := json.Marshal(e)
+	return string(s)
+}
+
+// GoString returns the string representation.
+func (e *Event) GoString() string {
+	return e.String()
+}
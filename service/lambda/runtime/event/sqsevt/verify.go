@@ -0,0 +1,94 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package sqsevt
+
+import (
+	"crypto/md5"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// ErrMD5Mismatch is returned when the MD5 digest of a message does not match
+// its content.
+var ErrMD5Mismatch = errors.New("sqsevt: MD5 digest mismatch")
+
+// The transport types of the message attribute values, as used to compute the
+// MD5 digest of the message attributes.
+const (
+	transportString byte = 1
+	transportBinary byte = 2
+)
+
+// VerifyMD5 checks the message body against its MD5 digest and, when present,
+// the custom message attributes against theirs. It returns ErrMD5Mismatch if
+// the content has been altered.
+func (e *EventRecord) VerifyMD5() error {
+	sum := md5.Sum([]byte(e.Body))
+	if !strings.EqualFold(hex.EncodeToString(sum[:]), e.MD5OfBody) {
+		return ErrMD5Mismatch
+	}
+
+	if e.MD5OfMessageAttributes == "" {
+		return nil
+	}
+	sum = md5.Sum(encodeAttributes(e.MessageAttributes))
+	if !strings.EqualFold(hex.EncodeToString(sum[:]), e.MD5OfMessageAttributes) {
+		return ErrMD5Mismatch
+	}
+	return nil
+}
+
+// encodeAttributes returns the canonical encoding Amazon SQS digests for the
+// message attributes: for each attribute, sorted by name, the length-prefixed
+// name and data type, followed by the transport type and the length-prefixed
+// value.
+// See http://docs.aws.amazon.com/AWSSimpleQueueService/latest/SQSDeveloperGuide/sqs-message-attributes.html
+func encodeAttributes(attrs map[string]*MessageAttribute) []byte {
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf []byte
+	put := func(b []byte) {
+		var n [4]byte
+		binary.BigEndian.PutUint32(n[:], uint32(len(b)))
+		buf = append(buf, n[:]...)
+		buf = append(buf, b...)
+	}
+	for _, name := range names {
+		a := attrs[name]
+		put([]byte(name))
+		put([]byte(a.DataType))
+		if a.BaseType() == DataTypeBinary {
+			buf = append(buf, transportBinary)
+			put(a.BinaryValue)
+			continue
+		}
+		buf = append(buf, transportString)
+		if a.StringValue != nil {
+			put([]byte(*a.StringValue))
+		} else {
+			put(nil)
+		}
+	}
+	return buf
+}
@@ -0,0 +1,80 @@
+//
+// Copyright 2016 Alsanium, SAS. or its affiliates. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package sqsevt
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+func readEvent(t *testing.T, file string) *Event {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var evt Event
+	if err := json.Unmarshal(data, &evt); err != nil {
+		t.Fatal(err)
+	}
+	return &evt
+}
+
+func TestVerifyMD5(t *testing.T) {
+	for _, file := range []string{"testdata/event-standard.json", "testdata/event-fifo.json"} {
+		for _, r := range readEvent(t, file).Records {
+			if err := r.VerifyMD5(); err != nil {
+				t.Errorf("%s: %s: %v", file, r.MessageID, err)
+			}
+		}
+	}
+}
+
+func TestVerifyMD5Mismatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(*EventRecord)
+	}{
+		{"body", func(r *EventRecord) { r.Body += " " }},
+		{"string value", func(r *EventRecord) { *r.MessageAttributes["City"].StringValue = "Other City" }},
+		{"number value", func(r *EventRecord) { *r.MessageAttributes["Temperature"].StringValue = "23.50" }},
+		{"binary value", func(r *EventRecord) { r.MessageAttributes["Logo"].BinaryValue[0] = 0xff }},
+		{"custom type", func(r *EventRecord) { r.MessageAttributes["Owner"].DataType = "String" }},
+		{"name", func(r *EventRecord) {
+			r.MessageAttributes["Town"] = r.MessageAttributes["City"]
+			delete(r.MessageAttributes, "City")
+		}},
+		{"removed", func(r *EventRecord) { delete(r.MessageAttributes, "Thumbnail") }},
+		{"digest", func(r *EventRecord) { r.MD5OfMessageAttributes = r.MD5OfBody }},
+	}
+
+	for _, tt := range tests {
+		r := readEvent(t, "testdata/event-fifo.json").Records[0]
+		tt.tamper(r)
+		if err := r.VerifyMD5(); err != ErrMD5Mismatch {
+			t.Errorf("%s: got %v, want %v", tt.name, err, ErrMD5Mismatch)
+		}
+	}
+}
+
+func TestVerifyMD5Case(t *testing.T) {
+	r := readEvent(t, "testdata/event-standard.json").Records[0]
+	r.MD5OfBody = "E4E68FB7BD0E697A0AE8F1BB342846B3"
+	if err := r.VerifyMD5(); err != nil {
+		t.Error(err)
+	}
+}